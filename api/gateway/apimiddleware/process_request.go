@@ -197,6 +197,12 @@ func WriteMiddlewareResponseHeadersAndBody(grpcResp *http.Response, responseJson
 				statusCodeHeader = vs[0]
 			} else if strings.HasSuffix(h, api.VersionHeader) {
 				w.Header().Set(api.VersionHeader, vs[0])
+			} else if strings.HasSuffix(h, api.SigningDomainHeader) {
+				w.Header().Set(api.SigningDomainHeader, vs[0])
+			} else if strings.HasSuffix(h, api.SigningRootHeader) {
+				w.Header().Set(api.SigningRootHeader, vs[0])
+			} else if strings.HasSuffix(h, api.CacheControlHeader) {
+				w.Header().Set(api.CacheControlHeader, vs[0])
 			}
 		} else {
 			for _, v := range vs {
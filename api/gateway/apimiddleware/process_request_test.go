@@ -283,6 +283,7 @@ func TestWriteMiddlewareResponseHeadersAndBody(t *testing.T) {
 				"Foo": []string{"foo"},
 				grpc.WithPrefix(grpc.HttpCodeMetadataKey): []string{"204"},
 				grpc.WithPrefix(api.VersionHeader):        []string{"capella"},
+				grpc.WithPrefix(api.CacheControlHeader):   []string{"no-store"},
 			},
 		}
 		container := defaultResponseContainer()
@@ -304,6 +305,9 @@ func TestWriteMiddlewareResponseHeadersAndBody(t *testing.T) {
 		v, ok = writer.Header()["Eth-Consensus-Version"]
 		require.Equal(t, true, ok, "header not found")
 		assert.Equal(t, "capella", v[0])
+		v, ok = writer.Header()["Cache-Control"]
+		require.Equal(t, true, ok, "header not found")
+		assert.Equal(t, "no-store", v[0])
 		assert.Equal(t, 204, writer.Code)
 		assert.DeepEqual(t, responseJson, writer.Body.Bytes())
 	})
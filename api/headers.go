@@ -4,4 +4,10 @@ const (
 	VersionHeader        = "Eth-Consensus-Version"
 	JsonMediaType        = "application/json"
 	OctetStreamMediaType = "application/octet-stream"
+	// IncludeSigningInfoHeader asks block production endpoints to return the signing domain and signing
+	// root of the produced block in the SigningDomainHeader and SigningRootHeader headers.
+	IncludeSigningInfoHeader = "Eth-Include-Signing-Info"
+	SigningDomainHeader      = "Eth-Signing-Domain"
+	SigningRootHeader        = "Eth-Signing-Root"
+	CacheControlHeader       = "Cache-Control"
 )
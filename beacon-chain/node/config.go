@@ -4,12 +4,17 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	fastssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	tracing2 "github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/urfave/cli/v2"
 )
 
@@ -201,3 +206,45 @@ func configureExecutionSetting(cliCtx *cli.Context) error {
 func configureFastSSZHashingAlgorithm() {
 	fastssz.EnableVectorizedHTR = true
 }
+
+// excludedBuilderPubkeys parses the public keys of builders whose bids must not be used for block construction.
+func excludedBuilderPubkeys(pubkeys []string) (map[[fieldparams.BLSPubkeyLength]byte]bool, error) {
+	excluded := make(map[[fieldparams.BLSPubkeyLength]byte]bool, len(pubkeys))
+	for _, pk := range pubkeys {
+		b, err := hexutil.Decode(pk)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not decode excluded builder public key %s", pk)
+		}
+		if len(b) != fieldparams.BLSPubkeyLength {
+			return nil, fmt.Errorf("excluded builder public key %s has length %d bytes, expected %d bytes", pk, len(b), fieldparams.BLSPubkeyLength)
+		}
+		excluded[bytesutil.ToBytes48(b)] = true
+	}
+	return excluded, nil
+}
+
+// blockPublishingForks parses the names of the forks whose blocks can be published. No names mean that
+// blocks of every fork are accepted, which is represented by a nil map.
+func blockPublishingForks(names []string) (map[int]bool, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	forks := make(map[int]bool, len(names))
+	for _, name := range names {
+		v, err := version.FromString(name)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse block publishing fork")
+		}
+		forks[v] = true
+	}
+	return forks, nil
+}
+
+// defaultGraffiti converts the graffiti included in produced blocks without a requested one. It must fit
+// into a block's 32 byte graffiti field.
+func defaultGraffiti(graffiti string) ([32]byte, error) {
+	if len(graffiti) > 32 {
+		return [32]byte{}, fmt.Errorf("default graffiti %s has length %d bytes, expected at most 32 bytes", graffiti, len(graffiti))
+	}
+	return bytesutil.ToBytes32([]byte(graffiti)), nil
+}
@@ -11,8 +11,11 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	logTest "github.com/sirupsen/logrus/hooks/test"
@@ -228,3 +231,64 @@ func TestConfigureInterop(t *testing.T) {
 		})
 	}
 }
+
+func TestExcludedBuilderPubkeys(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		pk := "0x" + strings.Repeat("ab", fieldparams.BLSPubkeyLength)
+		excluded, err := excludedBuilderPubkeys([]string{pk})
+		require.NoError(t, err)
+		var want [fieldparams.BLSPubkeyLength]byte
+		for i := range want {
+			want[i] = 0xab
+		}
+		assert.Equal(t, 1, len(excluded))
+		assert.Equal(t, true, excluded[want])
+	})
+	t.Run("none", func(t *testing.T) {
+		excluded, err := excludedBuilderPubkeys(nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(excluded))
+	})
+	t.Run("invalid hex", func(t *testing.T) {
+		_, err := excludedBuilderPubkeys([]string{"foo"})
+		assert.ErrorContains(t, "could not decode excluded builder public key foo", err)
+	})
+	t.Run("wrong length", func(t *testing.T) {
+		_, err := excludedBuilderPubkeys([]string{"0xabcd"})
+		assert.ErrorContains(t, "excluded builder public key 0xabcd has length 2 bytes, expected 48 bytes", err)
+	})
+}
+
+func TestBlockPublishingForks(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		forks, err := blockPublishingForks([]string{"bellatrix", "capella"})
+		require.NoError(t, err)
+		assert.DeepEqual(t, map[int]bool{version.Bellatrix: true, version.Capella: true}, forks)
+	})
+	t.Run("none", func(t *testing.T) {
+		forks, err := blockPublishingForks(nil)
+		require.NoError(t, err)
+		assert.Equal(t, true, forks == nil)
+	})
+	t.Run("unknown fork", func(t *testing.T) {
+		_, err := blockPublishingForks([]string{"capella", "foo"})
+		assert.ErrorContains(t, "could not parse block publishing fork: foo", err)
+	})
+}
+
+func TestDefaultGraffiti(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		graffiti, err := defaultGraffiti("operator")
+		require.NoError(t, err)
+		assert.Equal(t, bytesutil.ToBytes32([]byte("operator")), graffiti)
+	})
+	t.Run("none", func(t *testing.T) {
+		graffiti, err := defaultGraffiti("")
+		require.NoError(t, err)
+		assert.Equal(t, [32]byte{}, graffiti)
+	})
+	t.Run("too long", func(t *testing.T) {
+		_, err := defaultGraffiti(strings.Repeat("a", 33))
+		assert.ErrorContains(t, "has length 33 bytes, expected at most 32 bytes", err)
+	})
+}
@@ -800,6 +800,18 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 
 	maxMsgSize := b.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
 	enableDebugRPCEndpoints := b.cliCtx.Bool(flags.EnableDebugRPCEndpoints.Name)
+	excludedBuilders, err := excludedBuilderPubkeys(b.cliCtx.StringSlice(flags.ExcludedBuilderPubkeys.Name))
+	if err != nil {
+		return err
+	}
+	acceptedForks, err := blockPublishingForks(b.cliCtx.StringSlice(flags.BlockPublishingForks.Name))
+	if err != nil {
+		return err
+	}
+	graffiti, err := defaultGraffiti(b.cliCtx.String(flags.DefaultGraffiti.Name))
+	if err != nil {
+		return err
+	}
 
 	p2pService := b.fetchP2P()
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
@@ -848,6 +860,15 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		MaxMsgSize:                    maxMsgSize,
 		ProposerIdsCache:              b.proposerIdsCache,
 		BlockBuilder:                  b.fetchBuilderService(),
+		ExcludedBuilders:              excludedBuilders,
+		StrictAmountValidation:        b.cliCtx.Bool(flags.StrictBlockAmountValidation.Name),
+		AcceptedForks:                 acceptedForks,
+		PublishRequestTimeout:         b.cliCtx.Duration(flags.BlockPublishingTimeout.Name),
+		LenientJSONDecoding:           b.cliCtx.Bool(flags.LenientBlockJSONDecoding.Name),
+		MaxConsensusValidations:       b.cliCtx.Int(flags.MaxConcurrentConsensusValidations.Name),
+		SSZOnly:                       b.cliCtx.Bool(flags.SSZOnlyBlockPublishing.Name),
+		DefaultGraffiti:               graffiti,
+		ClockDisparityTolerance:       b.cliCtx.Duration(flags.BlockPublishingClockDisparity.Name),
 		Router:                        router,
 		ClockWaiter:                   b.clockWaiter,
 	})
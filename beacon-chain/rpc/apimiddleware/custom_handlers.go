@@ -52,14 +52,6 @@ func handleGetBeaconStateSSZV2(m *apimiddleware.ApiProxyMiddleware, endpoint api
 	return handleGetSSZ(m, endpoint, w, req, config)
 }
 
-func handleGetBeaconBlockSSZV2(m *apimiddleware.ApiProxyMiddleware, endpoint apimiddleware.Endpoint, w http.ResponseWriter, req *http.Request) (handled bool) {
-	config := sszConfig{
-		fileName:     "beacon_block.ssz",
-		responseJson: &VersionedSSZResponseJson{},
-	}
-	return handleGetSSZ(m, endpoint, w, req, config)
-}
-
 func handleGetBlindedBeaconBlockSSZ(
 	m *apimiddleware.ApiProxyMiddleware,
 	endpoint apimiddleware.Endpoint,
@@ -107,6 +99,21 @@ func handleProduceBlindedBlockSSZ(
 	return handleGetSSZ(m, endpoint, w, req, config)
 }
 
+// handleIncludeSigningInfo translates the include_signing_info query parameter into a request header
+// understood by the block production gRPC server. It never handles the request itself.
+func handleIncludeSigningInfo(_ *apimiddleware.ApiProxyMiddleware, _ apimiddleware.Endpoint, _ http.ResponseWriter, req *http.Request) (handled bool) {
+	query := req.URL.Query()
+	if !query.Has("include_signing_info") {
+		return false
+	}
+	if query.Get("include_signing_info") == "true" {
+		req.Header.Set(grpc.WithPrefix(api.IncludeSigningInfoHeader), "true")
+	}
+	query.Del("include_signing_info")
+	req.URL.RawQuery = query.Encode()
+	return false
+}
+
 func handleGetSSZ(
 	m *apimiddleware.ApiProxyMiddleware,
 	endpoint apimiddleware.Endpoint,
@@ -311,6 +318,12 @@ func writeSSZResponseHeaderAndBody(grpcResp *http.Response, w http.ResponseWrite
 		if strings.HasPrefix(h, "Grpc-Metadata") {
 			if h == "Grpc-Metadata-"+grpc.HttpCodeMetadataKey {
 				statusCodeHeader = vs[0]
+			} else if strings.HasSuffix(h, api.SigningDomainHeader) {
+				w.Header().Set(api.SigningDomainHeader, vs[0])
+			} else if strings.HasSuffix(h, api.SigningRootHeader) {
+				w.Header().Set(api.SigningRootHeader, vs[0])
+			} else if strings.HasSuffix(h, api.CacheControlHeader) {
+				w.Header().Set(api.CacheControlHeader, vs[0])
 			}
 		} else {
 			for _, v := range vs {
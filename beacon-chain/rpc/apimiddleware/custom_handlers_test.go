@@ -157,6 +157,30 @@ func TestPreparePostedSszData(t *testing.T) {
 	assert.Equal(t, api.JsonMediaType, request.Header.Get("Content-Type"))
 }
 
+func TestHandleIncludeSigningInfo(t *testing.T) {
+	t.Run("true", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example?randao_reveal=0x01&include_signing_info=true", nil)
+		handled := handleIncludeSigningInfo(nil, apimiddleware.Endpoint{}, nil, request)
+		assert.Equal(t, false, handled)
+		assert.Equal(t, "true", request.Header.Get(grpc.WithPrefix(api.IncludeSigningInfoHeader)))
+		assert.Equal(t, "randao_reveal=0x01", request.URL.RawQuery)
+	})
+	t.Run("false", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example?include_signing_info=false", nil)
+		handled := handleIncludeSigningInfo(nil, apimiddleware.Endpoint{}, nil, request)
+		assert.Equal(t, false, handled)
+		assert.Equal(t, "", request.Header.Get(grpc.WithPrefix(api.IncludeSigningInfoHeader)))
+		assert.Equal(t, "", request.URL.RawQuery)
+	})
+	t.Run("not provided", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example?randao_reveal=0x01", nil)
+		handled := handleIncludeSigningInfo(nil, apimiddleware.Endpoint{}, nil, request)
+		assert.Equal(t, false, handled)
+		assert.Equal(t, "", request.Header.Get(grpc.WithPrefix(api.IncludeSigningInfoHeader)))
+		assert.Equal(t, "randao_reveal=0x01", request.URL.RawQuery)
+	})
+}
+
 func TestSerializeMiddlewareResponseIntoSSZ(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		j := testSSZResponseJson{
@@ -2,31 +2,133 @@ package beacon
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api"
+	coreblocks "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/shared"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+	v1alpha1validator "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/validator"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
 	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
 	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	"github.com/prysmaticlabs/prysm/v4/proto/migration"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
 const (
 	broadcastValidationQueryParam               = "broadcast_validation"
 	broadcastValidationConsensus                = "consensus"
 	broadcastValidationConsensusAndEquivocation = "consensus_and_equivocation"
+	broadcastValidationSignature                = "signature"
+	waitForInclusionQueryParam                  = "wait_for_inclusion"
+	convertOnlyQueryParam                       = "convert_only"
+	stopOnErrorQueryParam                       = "stop_on_error"
+	// inclusionPollInterval is how often forkchoice is checked while waiting for a block to become canonical.
+	inclusionPollInterval = 100 * time.Millisecond
+	// maxAmountGwei is an upper bound on the total ether supply (currently around 120M ETH).
+	// No single deposit or withdrawal can legitimately exceed it.
+	maxAmountGwei = 200_000_000 * 1_000_000_000
+	// consensusValidationRetryAfter is the number of seconds after which clients are asked to retry
+	// publishing a block that was rejected because too many blocks were being validated.
+	consensusValidationRetryAfter = 1
 )
 
+var errTooManyConsensusValidations = errors.New("too many blocks are being validated, try again later")
+
+// Reasons reported in the failure of a consensus validation error response.
+const (
+	consensusFailureStateRoot   = "state_root_mismatch"
+	consensusFailureSignature   = "invalid_signature"
+	consensusFailureAttestation = "invalid_attestation"
+	consensusFailureBlock       = "invalid_block"
+)
+
+// consensusValidationError is a failed consensus validation, along with the specific reason of the failure.
+type consensusValidationError struct {
+	reason string
+	cause  error
+	err    error
+}
+
+func newConsensusValidationError(err error) *consensusValidationError {
+	return &consensusValidationError{
+		reason: consensusFailureReason(err),
+		cause:  err,
+		err:    errors.Wrap(err, "consensus validation failed"),
+	}
+}
+
+func (e *consensusValidationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *consensusValidationError) Unwrap() error {
+	return e.cause
+}
+
+// Messages with which core/transition wraps the failures that consensusFailureReason tells apart.
+// The state transition doesn't return typed errors, so a failure is recognized by an error in its
+// chain whose message starts with one of them.
+var (
+	stateRootFailureMessages   = []string{"could not validate state root, wanted: "}
+	signatureFailureMessages   = []string{"could not batch verify signature: ", "signature in block failed to verify"}
+	attestationFailureMessages = []string{"could not process block attestations: ", "could not process altair attestation: "}
+)
+
+// errCommitteeIndexOutOfRange is returned by validateCommitteeIndices for an attestation of a committee that doesn't exist.
+var errCommitteeIndexOutOfRange = errors.New("committee index out of range")
+
+// consensusFailureReason tells apart the failures of a consensus validation.
+func consensusFailureReason(err error) string {
+	switch {
+	case hasWrappedMessage(err, stateRootFailureMessages):
+		return consensusFailureStateRoot
+	case hasWrappedMessage(err, signatureFailureMessages):
+		return consensusFailureSignature
+	case errors.Is(err, errCommitteeIndexOutOfRange), hasWrappedMessage(err, attestationFailureMessages):
+		return consensusFailureAttestation
+	default:
+		return consensusFailureBlock
+	}
+}
+
+// hasWrappedMessage tells whether err, or an error it wraps, has a message starting with one of the given messages.
+func hasWrappedMessage(err error, messages []string) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		for _, m := range messages {
+			if strings.HasPrefix(err.Error(), m) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // PublishBlindedBlockV2 instructs the beacon node to use the components of the `SignedBlindedBeaconBlock` to construct and publish a
 // `SignedBeaconBlock` by swapping out the `transactions_root` for the corresponding full list of `transactions`.
 // The beacon node should broadcast a newly constructed `SignedBeaconBlock` to the beacon network,
@@ -38,11 +140,16 @@ const (
 // a `SignedBeaconBlock`. The broadcast behaviour may be adjusted via the `broadcast_validation`
 // query parameter.
 func (bs *Server) PublishBlindedBlockV2(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethodAllowed(w, r, http.MethodPost) {
+		return
+	}
 	if shared.IsSyncing(r.Context(), w, bs.SyncChecker, bs.HeadFetcher, bs.TimeFetcher, bs.OptimisticModeFetcher) {
 		return
 	}
+	r, cancel := bs.withRequestDeadline(r)
+	defer cancel()
 	isSSZ, err := http2.SszRequested(r)
-	if isSSZ && err == nil {
+	if (isSSZ && err == nil) || http2.SszSnappyRequested(r) {
 		publishBlindedBlockV2SSZ(bs, w, r)
 	} else {
 		publishBlindedBlockV2(bs, w, r)
@@ -50,247 +157,23 @@ func (bs *Server) PublishBlindedBlockV2(w http.ResponseWriter, r *http.Request)
 }
 
 func publishBlindedBlockV2SSZ(bs *Server, w http.ResponseWriter, r *http.Request) {
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		errJson := &http2.DefaultErrorJson{
-			Message: "Could not read request body: " + err.Error(),
-			Code:    http.StatusInternalServerError,
-		}
-		http2.WriteError(w, errJson)
-		return
-	}
-	capellaBlock := &ethpbv2.SignedBlindedBeaconBlockCapella{}
-	if err := capellaBlock.UnmarshalSSZ(body); err == nil {
-		v1block, err := migration.BlindedCapellaToV1Alpha1SignedBlock(capellaBlock)
-		if err != nil {
-			errJson := &http2.DefaultErrorJson{
-				Message: "Could not decode request body into consensus block: " + err.Error(),
-				Code:    http.StatusBadRequest,
-			}
-			http2.WriteError(w, errJson)
-			return
-		}
-		genericBlock := &eth.GenericSignedBeaconBlock{
-			Block: &eth.GenericSignedBeaconBlock_BlindedCapella{
-				BlindedCapella: v1block,
-			},
-		}
-		if err = bs.validateBroadcast(r, genericBlock); err != nil {
-			errJson := &http2.DefaultErrorJson{
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			}
-			http2.WriteError(w, errJson)
-			return
-		}
-		bs.proposeBlock(r.Context(), w, genericBlock)
-		return
-	}
-	bellatrixBlock := &ethpbv2.SignedBlindedBeaconBlockBellatrix{}
-	if err := bellatrixBlock.UnmarshalSSZ(body); err == nil {
-		v1block, err := migration.BlindedBellatrixToV1Alpha1SignedBlock(bellatrixBlock)
-		if err != nil {
-			errJson := &http2.DefaultErrorJson{
-				Message: "Could not decode request body into consensus block: " + err.Error(),
-				Code:    http.StatusBadRequest,
-			}
-			http2.WriteError(w, errJson)
-			return
-		}
-		genericBlock := &eth.GenericSignedBeaconBlock{
-			Block: &eth.GenericSignedBeaconBlock_BlindedBellatrix{
-				BlindedBellatrix: v1block,
-			},
-		}
-		if err = bs.validateBroadcast(r, genericBlock); err != nil {
-			errJson := &http2.DefaultErrorJson{
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			}
-			http2.WriteError(w, errJson)
-			return
-		}
-		bs.proposeBlock(r.Context(), w, genericBlock)
-		return
-	}
+	bs.publishBlockSSZ(w, r, sszBlindedBlockDecoders, sszBlindedBlockDecodingOrder)
+}
 
-	// blinded is not supported before bellatrix hardfork
-	altairBlock := &ethpbv2.SignedBeaconBlockAltair{}
-	if err := altairBlock.UnmarshalSSZ(body); err == nil {
-		v1block, err := migration.AltairToV1Alpha1SignedBlock(altairBlock)
-		if err != nil {
-			errJson := &http2.DefaultErrorJson{
-				Message: "Could not decode request body into consensus block: " + err.Error(),
-				Code:    http.StatusBadRequest,
-			}
-			http2.WriteError(w, errJson)
-			return
-		}
-		genericBlock := &eth.GenericSignedBeaconBlock{
-			Block: &eth.GenericSignedBeaconBlock_Altair{
-				Altair: v1block,
-			},
-		}
-		if err = bs.validateBroadcast(r, genericBlock); err != nil {
-			errJson := &http2.DefaultErrorJson{
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			}
-			http2.WriteError(w, errJson)
-			return
-		}
-		bs.proposeBlock(r.Context(), w, genericBlock)
+func publishBlindedBlockV2(bs *Server, w http.ResponseWriter, r *http.Request) {
+	if !bs.isJSONAccepted(w) {
 		return
 	}
-	phase0Block := &ethpbv1.SignedBeaconBlock{}
-	if err := phase0Block.UnmarshalSSZ(body); err == nil {
-		v1block, err := migration.V1ToV1Alpha1SignedBlock(phase0Block)
-		if err != nil {
-			errJson := &http2.DefaultErrorJson{
-				Message: "Could not decode request body into consensus block: " + err.Error(),
-				Code:    http.StatusBadRequest,
-			}
-			http2.WriteError(w, errJson)
-			return
-		}
-		genericBlock := &eth.GenericSignedBeaconBlock{
-			Block: &eth.GenericSignedBeaconBlock_Phase0{
-				Phase0: v1block,
-			},
-		}
-		if err = bs.validateBroadcast(r, genericBlock); err != nil {
-			errJson := &http2.DefaultErrorJson{
-				Message: err.Error(),
-				Code:    http.StatusBadRequest,
-			}
-			http2.WriteError(w, errJson)
-			return
-		}
-		bs.proposeBlock(r.Context(), w, genericBlock)
+	body, ok := readBody(w, r)
+	if !ok {
 		return
 	}
-	errJson := &http2.DefaultErrorJson{
-		Message: "Body does not represent a valid block type",
-		Code:    http.StatusBadRequest,
-	}
-	http2.WriteError(w, errJson)
-}
-
-func publishBlindedBlockV2(bs *Server, w http.ResponseWriter, r *http.Request) {
-	validate := validator.New()
-	body, err := io.ReadAll(r.Body)
+	blk, code, err := bs.decodeBlockJSON(r, body, jsonBlindedBlockParsers)
 	if err != nil {
-		errJson := &http2.DefaultErrorJson{
-			Message: "Could not read request body",
-			Code:    http.StatusInternalServerError,
-		}
-		http2.WriteError(w, errJson)
+		writePublishError(w, code, err)
 		return
 	}
-
-	var capellaBlock *SignedBlindedBeaconBlockCapella
-	if err = unmarshalStrict(body, &capellaBlock); err == nil {
-		if err = validate.Struct(capellaBlock); err == nil {
-			consensusBlock, err := capellaBlock.ToGeneric()
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			if err = bs.validateBroadcast(r, consensusBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, consensusBlock)
-			return
-		}
-	}
-
-	var bellatrixBlock *SignedBlindedBeaconBlockBellatrix
-	if err = unmarshalStrict(body, &bellatrixBlock); err == nil {
-		if err = validate.Struct(bellatrixBlock); err == nil {
-			consensusBlock, err := bellatrixBlock.ToGeneric()
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			if err = bs.validateBroadcast(r, consensusBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, consensusBlock)
-			return
-		}
-	}
-	var altairBlock *SignedBeaconBlockAltair
-	if err = unmarshalStrict(body, &altairBlock); err == nil {
-		if err = validate.Struct(altairBlock); err == nil {
-			consensusBlock, err := altairBlock.ToGeneric()
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			if err = bs.validateBroadcast(r, consensusBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, consensusBlock)
-			return
-		}
-	}
-	var phase0Block *SignedBeaconBlock
-	if err = unmarshalStrict(body, &phase0Block); err == nil {
-		if err = validate.Struct(phase0Block); err == nil {
-			consensusBlock, err := phase0Block.ToGeneric()
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			if err = bs.validateBroadcast(r, consensusBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, consensusBlock)
-			return
-		}
-	}
-
-	errJson := &http2.DefaultErrorJson{
-		Message: "Body does not represent a valid block type",
-		Code:    http.StatusBadRequest,
-	}
-	http2.WriteError(w, errJson)
+	bs.proposeBlock(r, w, blk)
 }
 
 // PublishBlockV2 instructs the beacon node to broadcast a newly signed beacon block to the beacon network,
@@ -300,334 +183,1457 @@ func publishBlindedBlockV2(bs *Server, w http.ResponseWriter, r *http.Request) {
 // before doing so, so as to aid timely delivery of the block. Should the block fail full
 // validation, a separate success response code (202) is used to indicate that the block was
 // successfully broadcast but failed integration. The broadcast behaviour may be adjusted via the
-// `broadcast_validation` query parameter.
+// `broadcast_validation` query parameter. With the `wait_for_inclusion` query parameter set to true,
+// the response is only written once the block has become canonical, or with a 202 code if that
+// doesn't happen in time. With the `convert_only` query parameter set to true, the block isn't
+// broadcast at all and its SSZ encoding is returned instead, which is useful for tooling. A snappy framed
+// SSZ body is accepted with the application/octet-stream+snappy content type or the `encoding=ssz_snappy`
+// query parameter.
 func (bs *Server) PublishBlockV2(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethodAllowed(w, r, http.MethodPost) {
+		return
+	}
 	if shared.IsSyncing(r.Context(), w, bs.SyncChecker, bs.HeadFetcher, bs.TimeFetcher, bs.OptimisticModeFetcher) {
 		return
 	}
+	r, cancel := bs.withRequestDeadline(r)
+	defer cancel()
 	isSSZ, err := http2.SszRequested(r)
-	if isSSZ && err == nil {
+	if (isSSZ && err == nil) || http2.SszSnappyRequested(r) {
 		publishBlockV2SSZ(bs, w, r)
 	} else {
 		publishBlockV2(bs, w, r)
 	}
 }
 
-func publishBlockV2SSZ(bs *Server, w http.ResponseWriter, r *http.Request) {
-	validate := validator.New()
-	body, err := io.ReadAll(r.Body)
+// PublishBlockWithDetachedSignature publishes a block whose signature was produced separately from it.
+// The request body holds the unsigned block in `block` and its signature in `signature`. Both are
+// assembled into a signed block, which is then published exactly like a JSON body of PublishBlockV2,
+// including the handling of its query parameters.
+func (bs *Server) PublishBlockWithDetachedSignature(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethodAllowed(w, r, http.MethodPost) {
+		return
+	}
+	if !bs.isJSONAccepted(w) {
+		return
+	}
+	if shared.IsSyncing(r.Context(), w, bs.SyncChecker, bs.HeadFetcher, bs.TimeFetcher, bs.OptimisticModeFetcher) {
+		return
+	}
+	r, cancel := bs.withRequestDeadline(r)
+	defer cancel()
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	var req *DetachedSignatureBlock
+	if err := bs.unmarshalJSON(body, &req); err != nil {
+		writeErr(w, http.StatusBadRequest, "Could not decode request body: "+err.Error())
+		return
+	}
+	if len(req.Block) == 0 || string(req.Block) == "null" {
+		writeErr(w, http.StatusBadRequest, "block is required")
+		return
+	}
+	if _, err := decodeSignature("signature", req.Signature); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	signedBody, err := json.Marshal(&signedBlockJSON{
+		Message:   req.Block,
+		Signature: req.Signature,
+	})
 	if err != nil {
-		errJson := &http2.DefaultErrorJson{
-			Message: "Could not read request body",
-			Code:    http.StatusInternalServerError,
-		}
-		http2.WriteError(w, errJson)
+		writeErr(w, http.StatusInternalServerError, "Could not assemble signed block: "+err.Error())
 		return
 	}
-	capellaBlock := &ethpbv2.SignedBeaconBlockCapella{}
-	if err := capellaBlock.UnmarshalSSZ(body); err == nil {
-		if err = validate.Struct(capellaBlock); err == nil {
-			v1block, err := migration.CapellaToV1Alpha1SignedBlock(capellaBlock)
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			genericBlock := &eth.GenericSignedBeaconBlock{
-				Block: &eth.GenericSignedBeaconBlock_Capella{
-					Capella: v1block,
-				},
-			}
-			if err = bs.validateBroadcast(r, genericBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, genericBlock)
-			return
-		}
+	bs.publishBlockJSON(w, r, signedBody)
+}
+
+// signedBlockJSON is a signed block of any fork whose message is kept in its JSON encoding.
+type signedBlockJSON struct {
+	Message   json.RawMessage `json:"message"`
+	Signature string          `json:"signature"`
+}
+
+// PublishBlocks publishes a batch of blocks of the same fork, which is useful for replaying blocks.
+// The request body is a JSON array of signed blocks and the Eth-Consensus-Version header, which is
+// required, declares the fork of all of them. Each block is published in order exactly like a JSON
+// body of PublishBlockV2, and the response holds the result of each publication along with its
+// status code. With the `stop_on_error` query parameter set to true, the batch stops at the first
+// block that is not published successfully, and results are only returned for the blocks processed
+// until then.
+func (bs *Server) PublishBlocks(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethodAllowed(w, r, http.MethodPost) {
+		return
 	}
-	bellatrixBlock := &ethpbv2.SignedBeaconBlockBellatrix{}
-	if err := bellatrixBlock.UnmarshalSSZ(body); err == nil {
-		if err = validate.Struct(bellatrixBlock); err == nil {
-			v1block, err := migration.BellatrixToV1Alpha1SignedBlock(bellatrixBlock)
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			genericBlock := &eth.GenericSignedBeaconBlock{
-				Block: &eth.GenericSignedBeaconBlock_Bellatrix{
-					Bellatrix: v1block,
-				},
-			}
-			if err = bs.validateBroadcast(r, genericBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, genericBlock)
-			return
-		}
+	if !bs.isJSONAccepted(w) {
+		return
 	}
-	altairBlock := &ethpbv2.SignedBeaconBlockAltair{}
-	if err := altairBlock.UnmarshalSSZ(body); err == nil {
-		if err = validate.Struct(altairBlock); err == nil {
-			v1block, err := migration.AltairToV1Alpha1SignedBlock(altairBlock)
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			genericBlock := &eth.GenericSignedBeaconBlock{
-				Block: &eth.GenericSignedBeaconBlock_Altair{
-					Altair: v1block,
-				},
-			}
-			if err = bs.validateBroadcast(r, genericBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, genericBlock)
-			return
-		}
+	if shared.IsSyncing(r.Context(), w, bs.SyncChecker, bs.HeadFetcher, bs.TimeFetcher, bs.OptimisticModeFetcher) {
+		return
 	}
-	phase0Block := &ethpbv1.SignedBeaconBlock{}
-	if err := phase0Block.UnmarshalSSZ(body); err == nil {
-		if err = validate.Struct(phase0Block); err == nil {
-			v1block, err := migration.V1ToV1Alpha1SignedBlock(phase0Block)
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			genericBlock := &eth.GenericSignedBeaconBlock{
-				Block: &eth.GenericSignedBeaconBlock_Phase0{
-					Phase0: v1block,
-				},
-			}
-			if err = bs.validateBroadcast(r, genericBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, genericBlock)
-			return
-		}
+	if r.Header.Get(api.VersionHeader) == "" {
+		writeErr(w, http.StatusBadRequest, api.VersionHeader+" header is required")
+		return
+	}
+	if r.URL.Query().Get(convertOnlyQueryParam) == "true" {
+		writeErr(w, http.StatusBadRequest, convertOnlyQueryParam+" is not supported when publishing a batch of blocks")
+		return
+	}
+	r, cancel := bs.withRequestDeadline(r)
+	defer cancel()
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	var items []json.RawMessage
+	if err := bs.unmarshalJSON(body, &items); err != nil {
+		writeErr(w, http.StatusBadRequest, "Could not decode request body: "+err.Error())
+		return
+	}
+	if len(items) == 0 {
+		writeErr(w, http.StatusBadRequest, "No blocks provided")
+		return
 	}
-	errJson := &http2.DefaultErrorJson{
-		Message: "Body does not represent a valid block type",
-		Code:    http.StatusBadRequest,
+	stopOnError := r.URL.Query().Get(stopOnErrorQueryParam) == "true"
+
+	results := make([]*PublishBlocksResult, 0, len(items))
+	for _, item := range items {
+		result := bs.publishBatchItem(r, item)
+		results = append(results, result)
+		if stopOnError && result.Code != http.StatusOK {
+			break
+		}
 	}
-	http2.WriteError(w, errJson)
+	http2.WriteJson(w, &PublishBlocksResponse{Data: results})
 }
 
-func publishBlockV2(bs *Server, w http.ResponseWriter, r *http.Request) {
-	validate := validator.New()
-	body, err := io.ReadAll(r.Body)
+// publishBatchItem publishes a single block of a batch and turns the outcome into its result.
+func (bs *Server) publishBatchItem(r *http.Request, item []byte) *PublishBlocksResult {
+	resp, code, err := bs.submitBlockJSON(r, item)
 	if err != nil {
-		errJson := &http2.DefaultErrorJson{
-			Message: "Could not read request body",
-			Code:    http.StatusInternalServerError,
-		}
-		http2.WriteError(w, errJson)
+		return &PublishBlocksResult{Code: code, Message: err.Error()}
+	}
+	return &PublishBlocksResult{Code: code, Data: resp.Data}
+}
+
+func publishBlockV2SSZ(bs *Server, w http.ResponseWriter, r *http.Request) {
+	bs.publishBlockSSZ(w, r, sszBlockDecoders, sszBlockDecodingOrder)
+}
+
+// publishBlockSSZ decodes an SSZ encoded block using the provided per-fork decoders and proposes it.
+// If the request declares the block's fork, that fork must be the one scheduled at the block's slot,
+// and only its decoder is used. Otherwise the decoders are tried in decodingOrder until one of them
+// succeeds. Snappy framed SSZ is decoded first.
+func (bs *Server) publishBlockSSZ(w http.ResponseWriter, r *http.Request, decoders map[int]blockDecoder, decodingOrder []int) {
+	body, ok := readBody(w, r)
+	if !ok {
 		return
 	}
-	var capellaBlock *SignedBeaconBlockCapella
-	if err = unmarshalStrict(body, &capellaBlock); err == nil {
-		if err = validate.Struct(capellaBlock); err == nil {
-			consensusBlock, err := capellaBlock.ToGeneric()
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			if err = bs.validateBroadcast(r, consensusBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, consensusBlock)
+	if http2.SszSnappyRequested(r) {
+		body, ok = decodeSnappyBody(w, body)
+		if !ok {
 			return
 		}
 	}
-	var bellatrixBlock *SignedBeaconBlockBellatrix
-	if err = unmarshalStrict(body, &bellatrixBlock); err == nil {
-		if err = validate.Struct(bellatrixBlock); err == nil {
-			consensusBlock, err := bellatrixBlock.ToGeneric()
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			if err = bs.validateBroadcast(r, consensusBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, consensusBlock)
+	if r.Header.Get(api.VersionHeader) != "" {
+		if err := validateDeclaredFork(r.Header.Get(api.VersionHeader), body); err != nil {
+			writeErr(w, http.StatusBadRequest, "Invalid "+api.VersionHeader+" header: "+err.Error())
 			return
 		}
+		bs.publishBlockWithVersion(w, r, body, decoders)
+		return
 	}
-	var altairBlock *SignedBeaconBlockAltair
-	if err = unmarshalStrict(body, &altairBlock); err == nil {
-		if err = validate.Struct(altairBlock); err == nil {
-			consensusBlock, err := altairBlock.ToGeneric()
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			if err = bs.validateBroadcast(r, consensusBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, consensusBlock)
+
+	for _, v := range decodingOrder {
+		genericBlock, err := decoders[v](body)
+		if err != nil {
+			continue
+		}
+		if !bs.isForkAccepted(w, v) {
 			return
 		}
-	}
-	var phase0Block *SignedBeaconBlock
-	if err = unmarshalStrict(body, &phase0Block); err == nil {
-		if err = validate.Struct(phase0Block); err == nil {
-			consensusBlock, err := phase0Block.ToGeneric()
-			if err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: "Could not decode request body into consensus block: " + err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			if err = bs.validateBroadcast(r, consensusBlock); err != nil {
-				errJson := &http2.DefaultErrorJson{
-					Message: err.Error(),
-					Code:    http.StatusBadRequest,
-				}
-				http2.WriteError(w, errJson)
-				return
-			}
-			bs.proposeBlock(r.Context(), w, consensusBlock)
+		if err = bs.validateBroadcast(r, genericBlock); err != nil {
+			writeBroadcastValidationError(w, err)
 			return
 		}
+		bs.proposeBlock(r, w, genericBlock)
+		return
 	}
+	writeErr(w, http.StatusBadRequest, "Body does not represent a valid block type")
+}
 
-	errJson := &http2.DefaultErrorJson{
-		Message: "Body does not represent a valid block type",
-		Code:    http.StatusBadRequest,
+// validateDeclaredFork checks that the declared fork of an SSZ encoded signed block is the fork scheduled
+// at the block's slot, which is read directly from the encoding so that a mislabeled block is rejected
+// before being decoded. Unparsable headers and bodies too short to hold a slot are left for the decoders
+// to report.
+func validateDeclaredFork(versionHeader string, body []byte) error {
+	v, err := version.FromString(versionHeader)
+	if err != nil {
+		return nil
+	}
+	slot, ok := sszBlockSlot(body)
+	if !ok {
+		return nil
+	}
+	if scheduled := scheduledForkVersion(slots.ToEpoch(slot)); v != scheduled {
+		return errors.Errorf("block at slot %d belongs to the %s fork, not %s", slot, version.String(scheduled), version.String(v))
 	}
-	http2.WriteError(w, errJson)
+	return nil
 }
 
-func (bs *Server) proposeBlock(ctx context.Context, w http.ResponseWriter, blk *eth.GenericSignedBeaconBlock) {
-	_, err := bs.V1Alpha1ValidatorServer.ProposeBeaconBlock(ctx, blk)
-	if err != nil {
-		errJson := &http2.DefaultErrorJson{
-			Message: err.Error(),
-			Code:    http.StatusInternalServerError,
-		}
-		http2.WriteError(w, errJson)
-		return
+// sszBlockSlot reads the slot of an SSZ encoded signed block. The encoding starts with the offset of the
+// block message, whose first field is the slot.
+func sszBlockSlot(body []byte) (primitives.Slot, bool) {
+	if len(body) < 4 {
+		return 0, false
 	}
+	offset := uint64(binary.LittleEndian.Uint32(body[:4]))
+	if offset+8 > uint64(len(body)) {
+		return 0, false
+	}
+	return primitives.Slot(binary.LittleEndian.Uint64(body[offset : offset+8])), true
 }
 
-func unmarshalStrict(data []byte, v interface{}) error {
-	dec := json.NewDecoder(bytes.NewReader(data))
-	dec.DisallowUnknownFields()
-	return dec.Decode(v)
+// scheduledFork is a fork whose blocks can be published, along with the epoch it is scheduled at.
+type scheduledFork struct {
+	version int
+	epoch   primitives.Epoch
 }
 
-func (bs *Server) validateBroadcast(r *http.Request, blk *eth.GenericSignedBeaconBlock) error {
-	switch r.URL.Query().Get(broadcastValidationQueryParam) {
-	case broadcastValidationConsensus:
-		b, err := blocks.NewSignedBeaconBlock(blk.Block)
-		if err != nil {
-			return errors.Wrapf(err, "could not create signed beacon block")
-		}
-		if err = bs.validateConsensus(r.Context(), b); err != nil {
-			return errors.Wrap(err, "consensus validation failed")
-		}
-	case broadcastValidationConsensusAndEquivocation:
-		b, err := blocks.NewSignedBeaconBlock(blk.Block)
-		if err != nil {
-			return errors.Wrapf(err, "could not create signed beacon block")
-		}
-		if err = bs.validateConsensus(r.Context(), b); err != nil {
-			return errors.Wrap(err, "consensus validation failed")
-		}
-		if err = bs.validateEquivocation(b.Block()); err != nil {
-			return errors.Wrap(err, "equivocation validation failed")
+// blockForkSchedule returns the forks whose blocks can be published, oldest first, along with the
+// epochs they are scheduled at in the node's configuration.
+func blockForkSchedule() []scheduledFork {
+	cfg := params.BeaconConfig()
+	return []scheduledFork{
+		{version: version.Phase0, epoch: cfg.GenesisEpoch},
+		{version: version.Altair, epoch: cfg.AltairForkEpoch},
+		{version: version.Bellatrix, epoch: cfg.BellatrixForkEpoch},
+		{version: version.Capella, epoch: cfg.CapellaForkEpoch},
+	}
+}
+
+// scheduledForkVersion returns the version of the fork scheduled at the given epoch.
+func scheduledForkVersion(epoch primitives.Epoch) int {
+	schedule := blockForkSchedule()
+	for i := len(schedule) - 1; i > 0; i-- {
+		if epoch >= schedule[i].epoch {
+			return schedule[i].version
 		}
-	default:
-		return nil
 	}
-	return nil
+	return schedule[0].version
 }
 
-func (bs *Server) validateConsensus(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock) error {
-	parentBlockRoot := blk.Block().ParentRoot()
-	parentBlock, err := bs.Blocker.Block(ctx, parentBlockRoot[:])
+// publishBlockWithVersion decodes a block of the fork declared in the request's Eth-Consensus-Version
+// header using that fork's decoder, and proposes it. A 400 response is written when the header is
+// invalid or the body doesn't represent a block of the declared fork.
+func (bs *Server) publishBlockWithVersion(w http.ResponseWriter, r *http.Request, body []byte, decoders map[int]blockDecoder) {
+	blk, code, err := bs.decodeBlockWithVersion(r, body, decoders)
 	if err != nil {
-		return errors.Wrap(err, "could not get parent block")
+		writePublishError(w, code, err)
+		return
 	}
-	parentStateRoot := parentBlock.Block().StateRoot()
-	parentState, err := bs.Stater.State(ctx, parentStateRoot[:])
+	bs.proposeBlock(r, w, blk)
+}
+
+// decodeBlockWithVersion decodes a block of the fork declared in the request's Eth-Consensus-Version
+// header using that fork's decoder, and validates it for broadcast.
+func (bs *Server) decodeBlockWithVersion(
+	r *http.Request,
+	body []byte,
+	decoders map[int]blockDecoder,
+) (*eth.GenericSignedBeaconBlock, int, error) {
+	versionHeader := r.Header.Get(api.VersionHeader)
+	v, err := version.FromString(versionHeader)
 	if err != nil {
-		return errors.Wrap(err, "could not get parent state")
+		return nil, http.StatusBadRequest, errors.Wrap(err, "Could not parse "+api.VersionHeader+" header")
 	}
-	_, err = transition.ExecuteStateTransition(ctx, parentState, blk)
+	if err = bs.checkForkAccepted(v); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	decode, ok := decoders[v]
+	if !ok {
+		return nil, http.StatusBadRequest, errors.New("Unsupported " + api.VersionHeader + " header value " + versionHeader)
+	}
+	genericBlock, err := decode(body)
 	if err != nil {
-		return errors.Wrap(err, "could not execute state transition")
+		recordConversionError(err)
+		return nil, http.StatusBadRequest, errors.Wrap(err, "Body does not represent a valid "+versionHeader+" block")
+	}
+	if err = bs.validateBroadcast(r, genericBlock); err != nil {
+		return nil, broadcastValidationErrorCode(err), err
+	}
+	return genericBlock, http.StatusOK, nil
+}
+
+// blockDecoder decodes the encoding of a signed beacon block of a particular fork.
+type blockDecoder func(body []byte) (*eth.GenericSignedBeaconBlock, error)
+
+// sszBlockDecoders maps each fork version to the decoder of its signed beacon block.
+var sszBlockDecoders = map[int]blockDecoder{
+	version.Phase0:    decodePhase0BlockSSZ,
+	version.Altair:    decodeAltairBlockSSZ,
+	version.Bellatrix: decodeBellatrixBlockSSZ,
+	version.Capella:   decodeCapellaBlockSSZ,
+}
+
+// sszBlockDecodingOrder is the order in which decoders are tried when the fork of an SSZ block is not known.
+// Newer forks are tried first.
+var sszBlockDecodingOrder = []int{version.Capella, version.Bellatrix, version.Altair, version.Phase0}
+
+// sszBlindedBlockDecoders maps each fork version to the decoder of its signed blinded beacon block.
+// Blinded blocks don't exist before Bellatrix, so regular blocks are accepted for earlier forks.
+var sszBlindedBlockDecoders = map[int]blockDecoder{
+	version.Phase0:    decodePhase0BlockSSZ,
+	version.Altair:    decodeAltairBlockSSZ,
+	version.Bellatrix: decodeBlindedBellatrixBlockSSZ,
+	version.Capella:   decodeBlindedCapellaBlockSSZ,
+}
+
+// sszBlindedBlockDecodingOrder is the order in which decoders are tried when the fork of an SSZ blinded block is not known.
+// Newer forks are tried first.
+var sszBlindedBlockDecodingOrder = []int{version.Capella, version.Bellatrix, version.Altair, version.Phase0}
+
+// sszBlock is a block that can be decoded from and encoded to SSZ.
+type sszBlock interface {
+	UnmarshalSSZ(buf []byte) error
+	MarshalSSZ() ([]byte, error)
+}
+
+// unmarshalSSZStrict decodes body into blk and then re-encodes blk, failing when the result differs from body.
+// This prevents a block of one fork from being accepted as a block of another fork whose layout happens to
+// tolerate the same bytes.
+func unmarshalSSZStrict(body []byte, blk sszBlock) error {
+	if err := blk.UnmarshalSSZ(body); err != nil {
+		return err
+	}
+	enc, err := blk.MarshalSSZ()
+	if err != nil {
+		return errors.Wrap(err, "could not re-encode decoded block")
+	}
+	if !bytes.Equal(enc, body) {
+		return errors.New("decoded block does not re-encode to the request body")
+	}
+	return nil
+}
+
+func decodeCapellaBlockSSZ(body []byte) (*eth.GenericSignedBeaconBlock, error) {
+	capellaBlock := &ethpbv2.SignedBeaconBlockCapella{}
+	if err := unmarshalSSZStrict(body, capellaBlock); err != nil {
+		return nil, err
+	}
+	v1block, err := migration.CapellaToV1Alpha1SignedBlock(capellaBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode request body into consensus block")
+	}
+	return &eth.GenericSignedBeaconBlock{
+		Block: &eth.GenericSignedBeaconBlock_Capella{
+			Capella: v1block,
+		},
+	}, nil
+}
+
+func decodeBellatrixBlockSSZ(body []byte) (*eth.GenericSignedBeaconBlock, error) {
+	bellatrixBlock := &ethpbv2.SignedBeaconBlockBellatrix{}
+	if err := unmarshalSSZStrict(body, bellatrixBlock); err != nil {
+		return nil, err
+	}
+	v1block, err := migration.BellatrixToV1Alpha1SignedBlock(bellatrixBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode request body into consensus block")
+	}
+	return &eth.GenericSignedBeaconBlock{
+		Block: &eth.GenericSignedBeaconBlock_Bellatrix{
+			Bellatrix: v1block,
+		},
+	}, nil
+}
+
+func decodeBlindedCapellaBlockSSZ(body []byte) (*eth.GenericSignedBeaconBlock, error) {
+	capellaBlock := &ethpbv2.SignedBlindedBeaconBlockCapella{}
+	if err := unmarshalSSZStrict(body, capellaBlock); err != nil {
+		return nil, err
+	}
+	v1block, err := migration.BlindedCapellaToV1Alpha1SignedBlock(capellaBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode request body into consensus block")
+	}
+	return &eth.GenericSignedBeaconBlock{
+		Block: &eth.GenericSignedBeaconBlock_BlindedCapella{
+			BlindedCapella: v1block,
+		},
+	}, nil
+}
+
+func decodeBlindedBellatrixBlockSSZ(body []byte) (*eth.GenericSignedBeaconBlock, error) {
+	bellatrixBlock := &ethpbv2.SignedBlindedBeaconBlockBellatrix{}
+	if err := unmarshalSSZStrict(body, bellatrixBlock); err != nil {
+		return nil, err
+	}
+	v1block, err := migration.BlindedBellatrixToV1Alpha1SignedBlock(bellatrixBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode request body into consensus block")
+	}
+	return &eth.GenericSignedBeaconBlock{
+		Block: &eth.GenericSignedBeaconBlock_BlindedBellatrix{
+			BlindedBellatrix: v1block,
+		},
+	}, nil
+}
+
+func decodeAltairBlockSSZ(body []byte) (*eth.GenericSignedBeaconBlock, error) {
+	altairBlock := &ethpbv2.SignedBeaconBlockAltair{}
+	if err := unmarshalSSZStrict(body, altairBlock); err != nil {
+		return nil, err
+	}
+	v1block, err := migration.AltairToV1Alpha1SignedBlock(altairBlock)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode request body into consensus block")
+	}
+	return &eth.GenericSignedBeaconBlock{
+		Block: &eth.GenericSignedBeaconBlock_Altair{
+			Altair: v1block,
+		},
+	}, nil
+}
+
+func decodePhase0BlockSSZ(body []byte) (*eth.GenericSignedBeaconBlock, error) {
+	phase0Block := &ethpbv1.SignedBeaconBlock{}
+	if err := unmarshalSSZStrict(body, phase0Block); err != nil {
+		return nil, err
+	}
+	v1block, err := migration.V1ToV1Alpha1SignedBlock(phase0Block)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode request body into consensus block")
+	}
+	return &eth.GenericSignedBeaconBlock{
+		Block: &eth.GenericSignedBeaconBlock_Phase0{
+			Phase0: v1block,
+		},
+	}, nil
+}
+
+// jsonUnmarshaler parses JSON encoded data into the value pointed to by v.
+type jsonUnmarshaler func(data []byte, v interface{}) error
+
+// jsonBlockParser parses a JSON signed beacon block of a particular fork into its JSON structure.
+type jsonBlockParser func(body []byte, unmarshal jsonUnmarshaler) (genericBlock, error)
+
+// parseJSONBlock parses a JSON signed beacon block into the structure T and checks its required fields.
+func parseJSONBlock[T genericBlock](body []byte, unmarshal jsonUnmarshaler) (genericBlock, error) {
+	var blk T
+	if err := unmarshal(body, &blk); err != nil {
+		return nil, err
+	}
+	if err := validator.New().Struct(blk); err != nil {
+		return nil, err
+	}
+	return blk, nil
+}
+
+// jsonBlockParsers maps each fork version to the parser of its JSON signed beacon block.
+var jsonBlockParsers = map[int]jsonBlockParser{
+	version.Phase0:    parseJSONBlock[*SignedBeaconBlock],
+	version.Altair:    parseJSONBlock[*SignedBeaconBlockAltair],
+	version.Bellatrix: parseJSONBlock[*SignedBeaconBlockBellatrix],
+	version.Capella:   parseJSONBlock[*SignedBeaconBlockCapella],
+}
+
+// jsonBlindedBlockParsers maps each fork version to the parser of its JSON signed blinded beacon block.
+// Blinded blocks don't exist before Bellatrix, so regular blocks are accepted for earlier forks.
+var jsonBlindedBlockParsers = map[int]jsonBlockParser{
+	version.Phase0:    parseJSONBlock[*SignedBeaconBlock],
+	version.Altair:    parseJSONBlock[*SignedBeaconBlockAltair],
+	version.Bellatrix: parseJSONBlock[*SignedBlindedBeaconBlockBellatrix],
+	version.Capella:   parseJSONBlock[*SignedBlindedBeaconBlockCapella],
+}
+
+// jsonBlockDecodingOrder is the order in which parsers are tried when the fork of a JSON block is not known.
+// Newer forks are tried first.
+var jsonBlockDecodingOrder = []int{version.Capella, version.Bellatrix, version.Altair, version.Phase0}
+
+// jsonBlockDecoders maps each fork version to the decoder of its JSON signed beacon block, which parses
+// the body with the fork's parser using the provided unmarshaler.
+func jsonBlockDecoders(parsers map[int]jsonBlockParser, unmarshal jsonUnmarshaler) map[int]blockDecoder {
+	decoders := make(map[int]blockDecoder, len(parsers))
+	for v, parse := range parsers {
+		parse := parse
+		decoders[v] = func(body []byte) (*eth.GenericSignedBeaconBlock, error) {
+			blk, err := parse(body, unmarshal)
+			if err != nil {
+				return nil, err
+			}
+			return blk.ToGeneric()
+		}
+	}
+	return decoders
+}
+
+// publishBlockV2 decodes a JSON encoded block and proposes it. If the request declares the block's fork,
+// the body is decoded directly into that fork's block. Otherwise each fork is tried, newest first.
+func publishBlockV2(bs *Server, w http.ResponseWriter, r *http.Request) {
+	if !bs.isJSONAccepted(w) {
+		return
+	}
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	bs.publishBlockJSON(w, r, body)
+}
+
+// publishBlockJSON decodes a JSON encoded signed block of any fork and proposes it.
+func (bs *Server) publishBlockJSON(w http.ResponseWriter, r *http.Request, body []byte) {
+	if r.URL.Query().Get(convertOnlyQueryParam) == "true" {
+		blk, code, err := bs.decodeBlockJSON(r, body, jsonBlockParsers)
+		if err != nil {
+			writePublishError(w, code, err)
+			return
+		}
+		writeConvertedBlock(w, r, blk)
+		return
+	}
+	resp, code, err := bs.submitBlockJSON(r, body)
+	writePublishResult(w, resp, code, err)
+}
+
+// submitBlockJSON decodes a JSON encoded signed block of any fork and proposes it. It returns the
+// response along with its status code, or the error and the status code it should be reported with.
+func (bs *Server) submitBlockJSON(r *http.Request, body []byte) (*PublishBlockResponse, int, error) {
+	blk, code, err := bs.decodeBlockJSON(r, body, jsonBlockParsers)
+	if err != nil {
+		return nil, code, err
+	}
+	return bs.submitBlock(r, blk)
+}
+
+// decodeBlockJSON decodes a JSON encoded signed block of any fork, parsed with the given parsers, and
+// validates it for broadcast. If the request declares the block's fork, the body is decoded directly
+// into that fork's block. Otherwise each fork is tried, newest first.
+func (bs *Server) decodeBlockJSON(r *http.Request, body []byte, parsers map[int]jsonBlockParser) (*eth.GenericSignedBeaconBlock, int, error) {
+	if err := validateNotBlockContents(body); err != nil {
+		return nil, http.StatusBadRequest, errors.Wrap(err, "Block contents are not supported")
+	}
+	if err := validateExecutionPayloadPresence(body); err != nil {
+		return nil, http.StatusBadRequest, errors.Wrap(err, "Ambiguous block")
+	}
+	if r.Header.Get(api.VersionHeader) != "" {
+		return bs.decodeBlockWithVersion(r, body, jsonBlockDecoders(parsers, bs.unmarshalJSON))
+	}
+	for _, v := range jsonBlockDecodingOrder {
+		if blk, err := parsers[v](body, bs.unmarshalJSON); err == nil {
+			return bs.acceptDecodedBlock(r, v, blk.ToGeneric)
+		}
+	}
+	return nil, http.StatusBadRequest, errors.New("Body does not represent a valid block type")
+}
+
+// acceptDecodedBlock converts a block decoded into the JSON structure of fork v into a consensus block
+// and validates it for broadcast.
+func (bs *Server) acceptDecodedBlock(
+	r *http.Request,
+	v int,
+	toGeneric func() (*eth.GenericSignedBeaconBlock, error),
+) (*eth.GenericSignedBeaconBlock, int, error) {
+	if err := bs.checkForkAccepted(v); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	blk, err := toGeneric()
+	if err != nil {
+		recordConversionError(err)
+		return nil, http.StatusBadRequest, errors.Wrap(err, "Could not decode request body into consensus block")
+	}
+	if err = bs.validateBroadcast(r, blk); err != nil {
+		return nil, broadcastValidationErrorCode(err), err
+	}
+	return blk, http.StatusOK, nil
+}
+
+// proposeBlock proposes a block that passed broadcast validation and writes out the response, or writes
+// out the block's SSZ encoding when the request only asks for the conversion.
+func (bs *Server) proposeBlock(r *http.Request, w http.ResponseWriter, blk *eth.GenericSignedBeaconBlock) {
+	if r.URL.Query().Get(convertOnlyQueryParam) == "true" {
+		writeConvertedBlock(w, r, blk)
+		return
+	}
+	resp, code, err := bs.submitBlock(r, blk)
+	writePublishResult(w, resp, code, err)
+}
+
+// submitBlock proposes a block that passed broadcast validation. It returns the response along with its
+// status code, which is 202 when the request waits for the block's inclusion and it is not included in
+// time, or the error and the status code it should be reported with.
+func (bs *Server) submitBlock(r *http.Request, blk *eth.GenericSignedBeaconBlock) (*PublishBlockResponse, int, error) {
+	ctx := r.Context()
+	_, err := bs.V1Alpha1ValidatorServer.ProposeBeaconBlock(ctx, blk)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, http.StatusGatewayTimeout, errors.Wrap(err, "Timed out while proposing block")
+		}
+		if errors.Is(err, v1alpha1validator.ErrPayloadMismatch) {
+			return nil, http.StatusBadRequest, errors.Wrap(err, "Could not unblind block")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+	signedBlk, err := blocks.NewSignedBeaconBlock(blk.Block)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrap(err, "Could not get signed beacon block")
+	}
+	root, err := genericBlockRoot(blk)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrap(err, "Could not compute block root")
+	}
+	resp := &PublishBlockResponse{
+		Data: &PublishBlockResponseData{
+			Slot: fmt.Sprintf("%d", signedBlk.Block().Slot()),
+			Root: hexutil.Encode(root[:]),
+		},
+	}
+	if r.URL.Query().Get(waitForInclusionQueryParam) != "true" {
+		return resp, http.StatusOK, nil
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, bs.inclusionTimeout())
+	defer cancel()
+	head, ok := bs.waitForInclusion(waitCtx, root, signedBlk.Block().Slot())
+	if !ok {
+		return resp, http.StatusAccepted, nil
+	}
+	resp.Data.Head = hexutil.Encode(head)
+	return resp, http.StatusOK, nil
+}
+
+// writePublishResult writes out the result of publishing a block.
+func writePublishResult(w http.ResponseWriter, resp *PublishBlockResponse, code int, err error) {
+	if err != nil {
+		writePublishError(w, code, err)
+		return
+	}
+	http2.WriteJsonWithStatus(w, code, resp)
+}
+
+// writeConvertedBlock writes out the SSZ encoding of a block instead of broadcasting it.
+func writeConvertedBlock(w http.ResponseWriter, r *http.Request, blk *eth.GenericSignedBeaconBlock) {
+	signedBlk, err := blocks.NewSignedBeaconBlock(blk.Block)
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "Could not get signed beacon block: "+err.Error())
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(signedBlk.Version()))
+	writeBlockSSZ(w, r, signedBlk)
+}
+
+// writeBlockSSZ writes out the SSZ encoding of a block, snappy framed if the request asks for it.
+func writeBlockSSZ(w http.ResponseWriter, r *http.Request, blk http2.SszMarshaler) {
+	var err error
+	if http2.SszSnappyRequested(r) {
+		err = http2.WriteSszSnappyFrom(w, blk, "beacon_block.ssz_snappy")
+	} else {
+		err = http2.WriteSszFrom(w, blk, "beacon_block.ssz")
+	}
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "Could not marshal block into SSZ: "+err.Error())
+	}
+}
+
+// inclusionTimeout is how long a published block is waited for to become canonical.
+// It defaults to the duration of a slot.
+func (bs *Server) inclusionTimeout() time.Duration {
+	if bs.InclusionTimeout == 0 {
+		return time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second
+	}
+	return bs.InclusionTimeout
+}
+
+// waitForInclusion polls forkchoice until the block with the given root and slot is canonical.
+// It returns the head root at that point, or false if the context is done before it happens.
+func (bs *Server) waitForInclusion(ctx context.Context, root [32]byte, slot primitives.Slot) ([]byte, bool) {
+	ticker := time.NewTicker(inclusionPollInterval)
+	defer ticker.Stop()
+	for {
+		if head, ok := bs.canonicalHead(ctx, root, slot); ok {
+			return head, true
+		}
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-ticker.C:
+		}
+	}
+}
+
+// canonicalHead returns the current head root if the block with the given root and slot is the head
+// or one of its ancestors.
+func (bs *Server) canonicalHead(ctx context.Context, root [32]byte, slot primitives.Slot) ([]byte, bool) {
+	head, err := bs.HeadFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, false
+	}
+	if bytes.Equal(head, root[:]) {
+		return head, true
+	}
+	// The lookup fails while the head hasn't been inserted into forkchoice yet,
+	// which only means that the block isn't canonical so far.
+	ancestor, err := bs.ForkchoiceFetcher.Ancestor(ctx, head, slot)
+	if err != nil {
+		return nil, false
+	}
+	return head, bytes.Equal(ancestor, root[:])
+}
+
+// withRequestDeadline bounds the lifetime of the request's context by RequestTimeout, so that
+// downstream calls made on behalf of a client without a timeout cannot hang indefinitely.
+// If RequestTimeout is not set, the request is returned unchanged.
+func (bs *Server) withRequestDeadline(r *http.Request) (*http.Request, context.CancelFunc) {
+	if bs.RequestTimeout == 0 {
+		return r, func() {}
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), bs.RequestTimeout)
+	return r.WithContext(ctx), cancel
+}
+
+// isForkAccepted checks whether blocks of the given fork can be published. If they can't, a 400
+// response naming the fork is written out.
+func (bs *Server) isForkAccepted(w http.ResponseWriter, v int) bool {
+	if err := bs.checkForkAccepted(v); err != nil {
+		writeErr(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+// checkForkAccepted returns an error naming the fork if blocks of the given fork can't be published.
+// If AcceptedForks is not set, blocks of every fork are accepted.
+func (bs *Server) checkForkAccepted(v int) error {
+	if bs.AcceptedForks == nil || bs.AcceptedForks[v] {
+		return nil
+	}
+	return errors.Errorf("Publishing %s blocks is not allowed", version.String(v))
+}
+
+// isJSONAccepted checks whether blocks can be published as JSON. If SSZOnly is set, a 415 response
+// directing the client to the SSZ encoding is written out.
+func (bs *Server) isJSONAccepted(w http.ResponseWriter) bool {
+	if !bs.SSZOnly {
+		return true
+	}
+	writeErr(w, http.StatusUnsupportedMediaType, "Publishing JSON blocks is disabled, use the "+api.OctetStreamMediaType+" content type instead")
+	return false
+}
+
+// validateNotBlockContents rejects JSON bodies shaped like block contents, which wrap a signed block along
+// with its blob sidecars, because only bare signed blocks can be published. Without this check they would
+// only be reported as not representing a valid block, which gives no hint of what is wrong with them.
+func validateNotBlockContents(body []byte) error {
+	var c struct {
+		SignedBlock               json.RawMessage `json:"signed_block"`
+		SignedBlobSidecars        json.RawMessage `json:"signed_blob_sidecars"`
+		SignedBlindedBlock        json.RawMessage `json:"signed_blinded_block"`
+		SignedBlindedBlobSidecars json.RawMessage `json:"signed_blinded_blob_sidecars"`
+	}
+	if err := json.Unmarshal(body, &c); err != nil {
+		return nil
+	}
+	if c.SignedBlobSidecars == nil && c.SignedBlindedBlobSidecars == nil {
+		return nil
+	}
+	switch {
+	case c.SignedBlock != nil:
+		return errors.New("body wraps a block and its blob sidecars, submit the block in signed_block on its own")
+	case c.SignedBlindedBlock != nil:
+		return errors.New("body wraps a blinded block and its blob sidecars, submit the block in signed_blinded_block on its own")
+	default:
+		return nil
+	}
+}
+
+// validateExecutionPayloadPresence rejects JSON blocks whose body contains both a full execution payload
+// and an execution payload header, because it's ambiguous whether such a block is meant to be blinded.
+// Bodies that aren't shaped like a block are left for the block decoders to report.
+func validateExecutionPayloadPresence(body []byte) error {
+	var b struct {
+		Message struct {
+			Body struct {
+				ExecutionPayload       json.RawMessage `json:"execution_payload"`
+				ExecutionPayloadHeader json.RawMessage `json:"execution_payload_header"`
+			} `json:"body"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal(body, &b); err != nil {
+		return nil
+	}
+	if b.Message.Body.ExecutionPayload != nil && b.Message.Body.ExecutionPayloadHeader != nil {
+		return errors.New("body contains both execution_payload and execution_payload_header")
+	}
+	return nil
+}
+
+// readBody reads the whole request body while making sure it does not exceed maxRequestBodySize.
+// A declared Content-Length is checked before anything is read, so that clients don't have
+// to upload the entire payload only to have it rejected. Bodies without a declared length
+// (e.g. chunked transfers) are capped by http.MaxBytesReader while streaming, and are fully
+// read before any decoding, so an oversized body is always reported as such. Bodies with
+// a gzip or deflate Content-Encoding are decompressed, and the limit applies to both the
+// compressed and the decompressed size.
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	maxSize := maxRequestBodySize()
+	if r.ContentLength > maxSize {
+		writeErrf(w, http.StatusRequestEntityTooLarge, "Request body size %d exceeds the maximum allowed size of %d bytes", r.ContentLength, maxSize)
+		return nil, false
+	}
+	var reader io.Reader = http.MaxBytesReader(w, r.Body, maxSize)
+	encoding := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding")))
+	compressed := encoding != "" && encoding != "identity"
+	var err error
+	switch encoding {
+	case "", "identity":
+	case "gzip":
+		reader, err = gzip.NewReader(reader)
+	case "deflate":
+		reader, err = zlib.NewReader(reader)
+	default:
+		writeErr(w, http.StatusUnsupportedMediaType, "Unsupported content encoding "+encoding)
+		return nil, false
+	}
+	var body []byte
+	if err == nil {
+		// The decompressed body is capped separately, as a small compressed body can expand
+		// to an arbitrary size.
+		body, err = io.ReadAll(io.LimitReader(reader, maxSize+1))
+	}
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeErrf(w, http.StatusRequestEntityTooLarge, "Request body exceeds the maximum allowed size of %d bytes", maxSize)
+			return nil, false
+		}
+		if compressed {
+			writeErr(w, http.StatusBadRequest, "Could not decompress request body: "+err.Error())
+			return nil, false
+		}
+		// A chunked body that ends before its terminating chunk was cut short by the client.
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			writeErr(w, http.StatusBadRequest, "Request body is incomplete: "+err.Error())
+			return nil, false
+		}
+		writeErr(w, http.StatusInternalServerError, "Could not read request body: "+err.Error())
+		return nil, false
+	}
+	if int64(len(body)) > maxSize {
+		writeErrf(w, http.StatusRequestEntityTooLarge, "Decompressed request body exceeds the maximum allowed size of %d bytes", maxSize)
+		return nil, false
+	}
+	if len(body) == 0 {
+		writeErr(w, http.StatusBadRequest, "Empty request body")
+		return nil, false
+	}
+	return body, true
+}
+
+// decodeSnappyBody decodes a snappy framed request body, capping the decoded size like readBody does.
+func decodeSnappyBody(w http.ResponseWriter, body []byte) ([]byte, bool) {
+	maxSize := maxRequestBodySize()
+	decoded, err := http2.DecodeSszSnappy(body, maxSize)
+	if errors.Is(err, http2.ErrSszSnappyTooLarge) {
+		writeErrf(w, http.StatusRequestEntityTooLarge, "Decompressed request body exceeds the maximum allowed size of %d bytes", maxSize)
+		return nil, false
+	}
+	if err != nil {
+		writeErr(w, http.StatusBadRequest, "Could not decode snappy framed request body: "+err.Error())
+		return nil, false
+	}
+	return decoded, true
+}
+
+// maxRequestBodySize is the largest block publish request body the node accepts.
+// Hex encoding in JSON roughly doubles the size of a block compared to SSZ,
+// so twice the maximum gossip message size is allowed.
+func maxRequestBodySize() int64 {
+	return 2 * int64(params.BeaconNetworkConfig().GossipMaxSizeBellatrix)
+}
+
+func unmarshalStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// unmarshalJSON parses a JSON request body. Unknown fields are rejected unless the server
+// has been configured to decode leniently, which helps with clients that already send fields
+// introduced by newer versions of the spec.
+func (bs *Server) unmarshalJSON(data []byte, v interface{}) error {
+	if bs.LenientJSONDecoding {
+		return json.Unmarshal(data, v)
+	}
+	return unmarshalStrict(data, v)
+}
+
+func (bs *Server) validateBroadcast(r *http.Request, blk *eth.GenericSignedBeaconBlock) error {
+	b, err := blocks.NewSignedBeaconBlock(blk.Block)
+	if err != nil {
+		return errors.Wrapf(err, "could not create signed beacon block")
+	}
+	if err = validateForkEpoch(b.Block()); err != nil {
+		return err
+	}
+	if bs.StrictAmountValidation {
+		if err = validateAmounts(b.Block()); err != nil {
+			return errors.Wrap(err, "amount validation failed")
+		}
+	}
+	switch r.URL.Query().Get(broadcastValidationQueryParam) {
+	case broadcastValidationSignature:
+		if err = bs.validateSignature(r.Context(), b); err != nil {
+			return errors.Wrap(err, "signature validation failed")
+		}
+	case broadcastValidationConsensus:
+		if err = bs.validateConsensus(r.Context(), b); err != nil {
+			return newConsensusValidationError(err)
+		}
+	case broadcastValidationConsensusAndEquivocation:
+		if err = bs.validateConsensus(r.Context(), b); err != nil {
+			return newConsensusValidationError(err)
+		}
+		if err = bs.validateEquivocation(b.Block()); err != nil {
+			return errors.Wrap(err, "equivocation validation failed")
+		}
+	default:
+		return nil
+	}
+	return nil
+}
+
+// validateForkEpoch checks that the fork of a block is already active at the block's slot. Without
+// this check, such a block would only be rejected with an opaque error when it's proposed.
+func validateForkEpoch(blk interfaces.ReadOnlyBeaconBlock) error {
+	cfg := params.BeaconConfig()
+	var forkEpoch primitives.Epoch
+	switch blk.Version() {
+	case version.Phase0:
+		return nil
+	case version.Altair:
+		forkEpoch = cfg.AltairForkEpoch
+	case version.Bellatrix:
+		forkEpoch = cfg.BellatrixForkEpoch
+	case version.Capella:
+		forkEpoch = cfg.CapellaForkEpoch
+	default:
+		return errors.Errorf("unsupported block version %s", version.String(blk.Version()))
+	}
+	if epoch := slots.ToEpoch(blk.Slot()); epoch < forkEpoch {
+		return errors.Errorf("%s block at slot %d is not allowed before the fork epoch %d", version.String(blk.Version()), blk.Slot(), forkEpoch)
+	}
+	return nil
+}
+
+// validateAmounts performs sanity checks on the deposit and withdrawal amounts of a block.
+// Amounts must be non-zero and must not exceed maxAmountGwei.
+func validateAmounts(blk interfaces.ReadOnlyBeaconBlock) error {
+	for i, d := range blk.Body().Deposits() {
+		if err := validateAmount(d.Data.Amount); err != nil {
+			return errors.Wrapf(err, "invalid amount of deposit %d", i)
+		}
+	}
+	if blk.Version() < version.Capella || blk.IsBlinded() {
+		return nil
+	}
+	payload, err := blk.Body().Execution()
+	if err != nil {
+		return errors.Wrap(err, "could not get execution payload")
+	}
+	withdrawals, err := payload.Withdrawals()
+	if err != nil {
+		return errors.Wrap(err, "could not get withdrawals")
+	}
+	for i, w := range withdrawals {
+		if err = validateAmount(w.Amount); err != nil {
+			return errors.Wrapf(err, "invalid amount of withdrawal %d", i)
+		}
+	}
+	return nil
+}
+
+func validateAmount(amount uint64) error {
+	if amount == 0 {
+		return errors.New("amount is zero")
+	}
+	if amount > maxAmountGwei {
+		return fmt.Errorf("amount %d exceeds the maximum of %d Gwei", amount, uint64(maxAmountGwei))
+	}
+	return nil
+}
+
+// acquireConsensusValidation reserves one of the slots for concurrent consensus validations, which run
+// a full state transition each. It returns false when all slots are taken, and a function releasing
+// the slot otherwise.
+func (bs *Server) acquireConsensusValidation() (func(), bool) {
+	if bs.MaxConcurrentConsensusValidations <= 0 {
+		return func() {}, true
+	}
+	if bs.consensusValidations.Add(1) > int32(bs.MaxConcurrentConsensusValidations) {
+		bs.consensusValidations.Add(-1)
+		return nil, false
+	}
+	return func() { bs.consensusValidations.Add(-1) }, true
+}
+
+// withinClockDisparity checks whether the given slot starts within ClockDisparityTolerance from now, in
+// which case a block of that slot is not considered to be from the future. This allows for proposers
+// whose clock is slightly ahead of the node's, like MAXIMUM_GOSSIP_CLOCK_DISPARITY does for gossip.
+func (bs *Server) withinClockDisparity(slot primitives.Slot) bool {
+	if bs.ClockDisparityTolerance <= 0 {
+		return false
+	}
+	slotStart, err := slots.ToTime(uint64(bs.TimeFetcher.GenesisTime().Unix()), slot)
+	if err != nil {
+		return false
+	}
+	return !bs.currentTime().Add(bs.ClockDisparityTolerance).Before(slotStart)
+}
+
+func (bs *Server) currentTime() time.Time {
+	if bs.now != nil {
+		return bs.now()
+	}
+	return time.Now()
+}
+
+// writeBroadcastValidationError writes the response of a block that failed broadcast validation.
+func writeBroadcastValidationError(w http.ResponseWriter, err error) {
+	writePublishError(w, broadcastValidationErrorCode(err), err)
+}
+
+// broadcastValidationErrorCode is the status code of a block that failed broadcast validation.
+// Validations rejected because the node is busy are reported as temporarily unavailable.
+func broadcastValidationErrorCode(err error) int {
+	if errors.Is(err, errTooManyConsensusValidations) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusBadRequest
+}
+
+// writePublishError writes the response of a block that could not be published. Clients are asked to
+// retry blocks rejected because the node is busy, and failed consensus validations additionally report
+// the specific reason of the failure.
+func writePublishError(w http.ResponseWriter, code int, err error) {
+	if errors.Is(err, errTooManyConsensusValidations) {
+		w.Header().Set("Retry-After", strconv.Itoa(consensusValidationRetryAfter))
+	}
+	var consensusErr *consensusValidationError
+	if errors.As(err, &consensusErr) {
+		http2.WriteJsonWithStatus(w, code, &ConsensusValidationErrorJson{
+			Message: err.Error(),
+			Code:    code,
+			Failure: &ConsensusValidationFailure{
+				Reason:  consensusErr.reason,
+				Message: consensusErr.cause.Error(),
+			},
+		})
+		return
+	}
+	writeErr(w, code, err.Error())
+}
+
+func (bs *Server) validateConsensus(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock) error {
+	// A block from before the finalized checkpoint can never become canonical,
+	// so there is no point in running the expensive state transition for it.
+	finalizedSlot, err := slots.EpochStart(bs.FinalizationFetcher.FinalizedCheckpt().Epoch)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized slot")
+	}
+	if blk.Block().Slot() < finalizedSlot {
+		return errors.Errorf("block slot %d is before the finalized slot %d", blk.Block().Slot(), finalizedSlot)
+	}
+	// The current slot is read from the time fetcher rather than the wall clock,
+	// which lets tests fix it.
+	if currentSlot := bs.TimeFetcher.CurrentSlot(); blk.Block().Slot() > currentSlot && !bs.withinClockDisparity(blk.Block().Slot()) {
+		return errors.Errorf("block slot %d is in the future, the current slot is %d", blk.Block().Slot(), currentSlot)
+	}
+	release, ok := bs.acquireConsensusValidation()
+	if !ok {
+		return errTooManyConsensusValidations
+	}
+	defer release()
+	parentState, err := bs.parentState(ctx, blk.Block())
+	if err != nil {
+		return err
+	}
+	if err = validateDepositCount(parentState, blk.Block()); err != nil {
+		return err
+	}
+	if err = validateCommitteeIndices(ctx, parentState, blk.Block()); err != nil {
+		return err
+	}
+	_, err = transition.ExecuteStateTransition(ctx, parentState, blk)
+	if err != nil {
+		return errors.Wrap(err, "could not execute state transition")
+	}
+	return nil
+}
+
+// validateSignature only verifies the proposer signature of the block, which is much cheaper
+// than a full state transition. The proposer's public key is read from the parent state.
+func (bs *Server) validateSignature(ctx context.Context, blk interfaces.ReadOnlySignedBeaconBlock) error {
+	parentState, err := bs.parentState(ctx, blk.Block())
+	if err != nil {
+		return err
+	}
+	if err = coreblocks.VerifyBlockSignatureUsingCurrentFork(parentState, blk); err != nil {
+		return errors.Wrap(err, "could not verify block signature")
+	}
+	return nil
+}
+
+func (bs *Server) parentState(ctx context.Context, blk interfaces.ReadOnlyBeaconBlock) (state.BeaconState, error) {
+	parentBlockRoot := blk.ParentRoot()
+	parentBlock, err := bs.Blocker.Block(ctx, parentBlockRoot[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get parent block")
+	}
+	parentStateRoot := parentBlock.Block().StateRoot()
+	parentState, err := bs.Stater.State(ctx, parentStateRoot[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get parent state")
+	}
+	return parentState, nil
+}
+
+// validateDepositCount checks that the block's eth1 data vote doesn't report fewer deposits than the
+// parent state already accounts for. The deposit contract only ever grows, so such a vote is invalid.
+func validateDepositCount(st state.ReadOnlyBeaconState, blk interfaces.ReadOnlyBeaconBlock) error {
+	parentCount := st.Eth1Data().DepositCount
+	if count := blk.Body().Eth1Data().DepositCount; count < parentCount {
+		return fmt.Errorf("eth1 data deposit count %d is lower than the parent state's deposit count %d", count, parentCount)
+	}
+	return nil
+}
+
+// validateCommitteeIndices checks that every attestation in the block refers to a committee
+// that exists at the attestation's slot, according to the validator registry of the given state.
+// This reports a common client bug with a clearer error than the state transition would.
+func validateCommitteeIndices(ctx context.Context, st state.ReadOnlyBeaconState, blk interfaces.ReadOnlyBeaconBlock) error {
+	committeeCounts := make(map[primitives.Epoch]uint64)
+	for i, a := range blk.Body().Attestations() {
+		epoch := slots.ToEpoch(a.Data.Slot)
+		count, ok := committeeCounts[epoch]
+		if !ok {
+			activeCount, err := helpers.ActiveValidatorCount(ctx, st, epoch)
+			if err != nil {
+				return errors.Wrapf(err, "could not get active validator count for epoch %d", epoch)
+			}
+			count = helpers.SlotCommitteeCount(activeCount)
+			committeeCounts[epoch] = count
+		}
+		if uint64(a.Data.CommitteeIndex) >= count {
+			return errors.Wrapf(errCommitteeIndexOutOfRange, "attestation %d has committee index %d but slot %d only has %d committees", i, a.Data.CommitteeIndex, a.Data.Slot, count)
+		}
 	}
 	return nil
 }
 
 func (bs *Server) validateEquivocation(blk interfaces.ReadOnlyBeaconBlock) error {
+	// The highest received slot reads as 0 until forkchoice has its first block, which must
+	// not be mistaken for a block at slot 0. Forkchoice only has no head while it is empty.
+	if bs.ForkchoiceFetcher.CachedHeadRoot() == [32]byte{} {
+		return nil
+	}
 	if bs.ForkchoiceFetcher.HighestReceivedBlockSlot() == blk.Slot() {
 		return fmt.Errorf("block for slot %d already exists in fork choice", blk.Slot())
 	}
 	return nil
 }
+
+// GetBlockV2HTTP retrieves the block for the given block ID. The block is returned as JSON, or as SSZ
+// when requested through the Accept header, and its fork is reported in the Eth-Consensus-Version header.
+// Snappy framed SSZ is returned for the application/octet-stream+snappy media type or the `encoding=ssz_snappy`
+// query parameter.
+func (bs *Server) GetBlockV2HTTP(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethodAllowed(w, r, http.MethodGet) {
+		return
+	}
+	blk, isOptimistic, isFinalized, ok := bs.blockForHTTP(w, r)
+	if !ok {
+		return
+	}
+	if blk.IsBlinded() {
+		fullBlk, err := bs.ExecutionPayloadReconstructor.ReconstructFullBlock(r.Context(), blk)
+		if err != nil {
+			writeErr(w, http.StatusInternalServerError, "Could not reconstruct full execution payload: "+err.Error())
+			return
+		}
+		blk = fullBlk
+	}
+	w.Header().Set(api.VersionHeader, version.String(blk.Version()))
+
+	isSSZ, err := http2.SszRequested(r)
+	if (isSSZ && err == nil) || http2.SszSnappyRequested(r) {
+		writeBlockSSZ(w, r, blk)
+		return
+	}
+	genericBlk, err := blk.PbGenericBlock()
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "Could not get generic block: "+err.Error())
+		return
+	}
+	data, ver, err := FromGeneric(genericBlk)
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "Could not convert block: "+err.Error())
+		return
+	}
+	http2.WriteJson(w, &GetBlockV2Response{
+		Version:             ver,
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Data:                data,
+	})
+}
+
+// GetBlockAttesterSlashings retrieves the attester slashings included in the requested block.
+// The slashings can be paged through with the `offset` and `limit` query parameters.
+func (bs *Server) GetBlockAttesterSlashings(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethodAllowed(w, r, http.MethodGet) {
+		return
+	}
+	page, ok := shared.PaginationFromRequest(w, r)
+	if !ok {
+		return
+	}
+	blk, isOptimistic, isFinalized, ok := bs.blockForHTTP(w, r)
+	if !ok {
+		return
+	}
+	slashings := blk.Block().Body().AttesterSlashings()
+	http2.WriteJson(w, &GetBlockAttesterSlashingsResponse{
+		Data:                convertInternalAttesterSlashings(shared.Paginate(slashings, page)),
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Total:               strconv.Itoa(len(slashings)),
+	})
+}
+
+// GetBlockProposerSlashings retrieves the proposer slashings included in the requested block.
+// The slashings can be paged through with the `offset` and `limit` query parameters.
+func (bs *Server) GetBlockProposerSlashings(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethodAllowed(w, r, http.MethodGet) {
+		return
+	}
+	page, ok := shared.PaginationFromRequest(w, r)
+	if !ok {
+		return
+	}
+	blk, isOptimistic, isFinalized, ok := bs.blockForHTTP(w, r)
+	if !ok {
+		return
+	}
+	slashings := blk.Block().Body().ProposerSlashings()
+	http2.WriteJson(w, &GetBlockProposerSlashingsResponse{
+		Data:                convertInternalProposerSlashings(shared.Paginate(slashings, page)),
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Total:               strconv.Itoa(len(slashings)),
+	})
+}
+
+// GetBlockExecutionPayload retrieves the execution payload of a block without the rest of the block. For blocks
+// that are stored blinded, the execution payload header is returned instead, which JSON responses indicate with
+// `blinded`. Blocks from before Bellatrix have no execution payload.
+func (bs *Server) GetBlockExecutionPayload(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethodAllowed(w, r, http.MethodGet) {
+		return
+	}
+	blk, isOptimistic, isFinalized, ok := bs.blockForHTTP(w, r)
+	if !ok {
+		return
+	}
+	if blk.Version() < version.Bellatrix {
+		writeErrf(w, http.StatusBadRequest, "Blocks of the %s fork have no execution payload", version.String(blk.Version()))
+		return
+	}
+	payload, err := blk.Block().Body().Execution()
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "Could not get execution payload: "+err.Error())
+		return
+	}
+	w.Header().Set(api.VersionHeader, version.String(blk.Version()))
+
+	isSSZ, err := http2.SszRequested(r)
+	if (isSSZ && err == nil) || http2.SszSnappyRequested(r) {
+		if http2.SszSnappyRequested(r) {
+			err = http2.WriteSszSnappyFrom(w, payload, "execution_payload.ssz_snappy")
+		} else {
+			err = http2.WriteSszFrom(w, payload, "execution_payload.ssz")
+		}
+		if err != nil {
+			writeErr(w, http.StatusInternalServerError, "Could not marshal execution payload into SSZ: "+err.Error())
+		}
+		return
+	}
+	data, err := convertInternalExecutionData(payload)
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "Could not convert execution payload: "+err.Error())
+		return
+	}
+	http2.WriteJson(w, &GetBlockExecutionPayloadResponse{
+		Version:             version.String(blk.Version()),
+		ExecutionOptimistic: isOptimistic,
+		Finalized:           isFinalized,
+		Blinded:             payload.IsBlinded(),
+		Data:                data,
+	})
+}
+
+// GetFork retrieves the fork active at the epoch given by the `epoch` query parameter, or at the epoch
+// of the slot given by the `slot` query parameter. It comes from the node's configuration and is what
+// clients need to build the signing domain of a block at that slot or epoch.
+func (bs *Server) GetFork(w http.ResponseWriter, r *http.Request) {
+	if !shared.IsMethodAllowed(w, r, http.MethodGet) {
+		return
+	}
+	epoch, ok := forkEpochFromRequest(w, r)
+	if !ok {
+		return
+	}
+	fork, err := forks.Fork(epoch)
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "Could not get fork: "+err.Error())
+		return
+	}
+	http2.WriteJson(w, &GetForkResponse{
+		Data: &Fork{
+			PreviousVersion: hexutil.Encode(fork.PreviousVersion),
+			CurrentVersion:  hexutil.Encode(fork.CurrentVersion),
+			Epoch:           strconv.FormatUint(uint64(fork.Epoch), 10),
+		},
+	})
+}
+
+// forkEpochFromRequest reads the epoch from the `epoch` query parameter, or derives it from the `slot`
+// query parameter. Exactly one of them must be provided.
+func forkEpochFromRequest(w http.ResponseWriter, r *http.Request) (primitives.Epoch, bool) {
+	rawEpoch := r.URL.Query().Get("epoch")
+	rawSlot := r.URL.Query().Get("slot")
+	switch {
+	case rawEpoch != "" && rawSlot != "":
+		writeErr(w, http.StatusBadRequest, "Only one of epoch and slot can be provided")
+		return 0, false
+	case rawEpoch != "":
+		epoch, ok := shared.ValidateUint(w, "epoch", rawEpoch)
+		return primitives.Epoch(epoch), ok
+	case rawSlot != "":
+		slot, ok := shared.ValidateUint(w, "slot", rawSlot)
+		return slots.ToEpoch(primitives.Slot(slot)), ok
+	default:
+		writeErr(w, http.StatusBadRequest, "Either epoch or slot is required")
+		return 0, false
+	}
+}
+
+// blockForHTTP looks up the block identified by the block_id URL parameter, along with its
+// optimistic and finalized status. An error response is written when the block can't be retrieved.
+func (bs *Server) blockForHTTP(w http.ResponseWriter, r *http.Request) (interfaces.ReadOnlySignedBeaconBlock, bool, bool, bool) {
+	blockId := mux.Vars(r)["block_id"]
+	if blockId == "" {
+		writeErr(w, http.StatusBadRequest, "block_id is required in URL params")
+		return nil, false, false, false
+	}
+	blk, blkRoot, err := bs.resolveBlockID(r.Context(), blockId)
+	if err != nil {
+		http2.WriteError(w, handleGetBlockHTTPError(err))
+		return nil, false, false, false
+	}
+	isOptimistic, err := bs.OptimisticModeFetcher.IsOptimisticForRoot(r.Context(), blkRoot)
+	if err != nil {
+		writeErr(w, http.StatusInternalServerError, "Could not check if block is optimistic: "+err.Error())
+		return nil, false, false, false
+	}
+	isFinalized := bs.FinalizationFetcher.IsFinalized(r.Context(), blkRoot)
+	w.Header().Set(api.CacheControlHeader, blockCacheControl(isFinalized))
+	if shared.IsNotModified(w, r, blockETag(blkRoot, isOptimistic, isFinalized)) {
+		return nil, false, false, false
+	}
+	// Only a finalized block that isn't optimistic never changes, and neither does its response, so it's
+	// considered to be last modified at the start of its slot. The statuses of other blocks, and the block
+	// an ID such as `head` resolves to, can change without the slot changing, so they aren't dated.
+	if isFinalized && !isOptimistic {
+		if slotStart, err := slots.ToTime(uint64(bs.GenesisTimeFetcher.GenesisTime().Unix()), blk.Block().Slot()); err == nil {
+			if shared.IsNotModifiedSince(w, r, slotStart) {
+				return nil, false, false, false
+			}
+		}
+	}
+	return blk, isOptimistic, isFinalized, true
+}
+
+// blockETag derives the entity tag of a block response from the block root. The optimistic and
+// finalized statuses are part of the response too, so a change in either invalidates the tag.
+func blockETag(root [32]byte, isOptimistic, isFinalized bool) string {
+	return fmt.Sprintf("\"%#x-%t-%t\"", root, isOptimistic, isFinalized)
+}
+
+// blockCacheControl returns the Cache-Control directive of a block response. A finalized block never
+// changes, but the block that IDs such as `finalized` resolve to does, so it's only cached for a slot.
+// Other blocks can be reorged out at any time and have to be revalidated against their ETag.
+func blockCacheControl(isFinalized bool) string {
+	if isFinalized {
+		return fmt.Sprintf("public, max-age=%d", params.BeaconConfig().SecondsPerSlot)
+	}
+	return "no-cache"
+}
+
+// writeErr writes an error response with the given status code and message.
+func writeErr(w http.ResponseWriter, code int, msg string) {
+	http2.WriteError(w, &http2.DefaultErrorJson{
+		Message: msg,
+		Code:    code,
+	})
+}
+
+// writeErrf writes an error response with the given status code and a message formatted
+// according to the format specifier.
+func writeErrf(w http.ResponseWriter, code int, format string, args ...interface{}) {
+	writeErr(w, code, fmt.Sprintf(format, args...))
+}
+
+func handleGetBlockHTTPError(err error) *http2.DefaultErrorJson {
+	var parseErr *lookup.BlockIdParseError
+	if errors.As(err, &parseErr) {
+		return &http2.DefaultErrorJson{
+			Message: "Invalid block ID: " + parseErr.Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	var notFoundErr *lookup.BlockNotFoundError
+	if errors.As(err, &notFoundErr) {
+		return &http2.DefaultErrorJson{
+			Message: "Could not find requested block: " + notFoundErr.Error(),
+			Code:    http.StatusNotFound,
+		}
+	}
+	return &http2.DefaultErrorJson{
+		Message: "Could not get block from block ID: " + err.Error(),
+		Code:    http.StatusInternalServerError,
+	}
+}
+
+// resolveBlockID retrieves the block and block root for a block ID as it appears in the URL of a
+// read endpoint. The ID is parsed with lookup.ParseBlockId. A *lookup.BlockIdParseError is returned
+// for a malformed ID, and a *lookup.BlockNotFoundError when no block matches the ID.
+func (bs *Server) resolveBlockID(ctx context.Context, id string) (interfaces.ReadOnlySignedBeaconBlock, [32]byte, error) {
+	blockId, err := lookup.ParseBlockId(id)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	blk, err := bs.Blocker.Block(ctx, blockId.Bytes())
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+	if err = blocks.BeaconBlockIsNil(blk); err != nil {
+		e := lookup.NewBlockNotFoundError(id)
+		return nil, [32]byte{}, &e
+	}
+	root, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not get block root")
+	}
+	return blk, root, nil
+}
@@ -0,0 +1,86 @@
+//go:build go1.18
+
+package beacon
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/api"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
+	mockSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+func FuzzPublishBlockV2SSZ(f *testing.F) {
+	addSSZSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte, fork uint8) {
+		fuzzPublishSSZ(t, data, fork, func(s *Server, w http.ResponseWriter, r *http.Request) { s.PublishBlockV2(w, r) })
+	})
+}
+
+func FuzzPublishBlindedBlockV2SSZ(f *testing.F) {
+	addSSZSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte, fork uint8) {
+		fuzzPublishSSZ(t, data, fork, func(s *Server, w http.ResponseWriter, r *http.Request) { s.PublishBlindedBlockV2(w, r) })
+	})
+}
+
+// fuzzVersionHeaders are the values of the Eth-Consensus-Version header picked by the fuzzed fork argument.
+// Without the header each fork is tried, with it the declared fork is checked against the block's slot.
+var fuzzVersionHeaders = []string{
+	"",
+	version.String(version.Phase0),
+	version.String(version.Altair),
+	version.String(version.Bellatrix),
+	version.String(version.Capella),
+}
+
+// addSSZSeeds seeds the corpus with the SSZ encoding of a valid block of every fork, both with and
+// without its fork declared.
+func addSSZSeeds(f *testing.F) {
+	seeds := []struct {
+		fixture string
+		block   interface{ MarshalSSZ() ([]byte, error) }
+		fork    uint8
+	}{
+		{phase0Block, &SignedBeaconBlock{}, 1},
+		{altairBlock, &SignedBeaconBlockAltair{}, 2},
+		{bellatrixBlock, &SignedBeaconBlockBellatrix{}, 3},
+		{blindedBellatrixBlock, &SignedBlindedBeaconBlockBellatrix{}, 3},
+		{capellaBlock, &SignedBeaconBlockCapella{}, 4},
+		{blindedCapellaBlock, &SignedBlindedBeaconBlockCapella{}, 4},
+	}
+	for _, s := range seeds {
+		if err := json.Unmarshal([]byte(s.fixture), s.block); err != nil {
+			f.Fatal(err)
+		}
+		sszBytes, err := s.block.MarshalSSZ()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(sszBytes, uint8(0))
+		f.Add(sszBytes, s.fork)
+	}
+}
+
+func fuzzPublishSSZ(t *testing.T, data []byte, fork uint8, handler func(*Server, http.ResponseWriter, *http.Request)) {
+	server := &Server{
+		V1Alpha1ValidatorServer: &testutil.MockValidatorServer{},
+		SyncChecker:             &mockSync.Sync{IsSyncing: false},
+	}
+	request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(data))
+	request.Header.Set("Accept", "application/octet-stream")
+	if v := fuzzVersionHeaders[int(fork)%len(fuzzVersionHeaders)]; v != "" {
+		request.Header.Set(api.VersionHeader, v)
+	}
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	handler(server, writer, request)
+	if writer.Code != http.StatusOK && writer.Code != http.StatusBadRequest {
+		t.Fatalf("unexpected status code %d: %s", writer.Code, writer.Body.String())
+	}
+}
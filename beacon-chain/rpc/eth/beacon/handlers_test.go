@@ -2,33 +2,57 @@ package beacon
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/api"
 	testing2 "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	dbTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+	v1alpha1validator "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/validator"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	mockSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	mock2 "github.com/prysmaticlabs/prysm/v4/testing/mock"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func TestPublishBlockV2(t *testing.T) {
+	activateForksAtGenesis(t)
 	ctrl := gomock.NewController(t)
 
 	t.Run("Phase 0", func(t *testing.T) {
@@ -99,6 +123,197 @@ func TestPublishBlockV2(t *testing.T) {
 		server.PublishBlockV2(writer, request)
 		assert.Equal(t, http.StatusOK, writer.Code)
 	})
+	t.Run("response contains block root", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_Capella)
+			return ok
+		}))
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		var blk SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &blk))
+		genericBlk, err := blk.ToGeneric()
+		require.NoError(t, err)
+		signedBlk, err := blocks.NewSignedBeaconBlock(genericBlk.Block)
+		require.NoError(t, err)
+		expectedRoot, err := signedBlk.Block().HashTreeRoot()
+		require.NoError(t, err)
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(capellaBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &PublishBlockResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, blk.Message.Slot, resp.Data.Slot)
+		assert.Equal(t, hexutil.Encode(expectedRoot[:]), resp.Data.Root)
+	})
+	t.Run("fake validator server", func(t *testing.T) {
+		v1alpha1Server := &testutil.MockValidatorServer{}
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(phase0Block)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, 1, len(v1alpha1Server.ProposedBlocks))
+		_, ok := v1alpha1Server.ProposedBlocks[0].Block.(*eth.GenericSignedBeaconBlock_Phase0)
+		assert.Equal(t, true, ok)
+	})
+	t.Run("proposal error", func(t *testing.T) {
+		server := &Server{
+			V1Alpha1ValidatorServer: &testutil.MockValidatorServer{ErrorToReturn: errors.New("foo")},
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(phase0Block)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusInternalServerError, writer.Code)
+		assert.StringContains(t, "foo", writer.Body.String())
+	})
+	t.Run("request deadline exceeded", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ *eth.GenericSignedBeaconBlock) (*eth.ProposeResponse, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			RequestTimeout:          10 * time.Millisecond,
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(phase0Block)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusGatewayTimeout, writer.Code)
+		assert.StringContains(t, "Timed out while proposing block", writer.Body.String())
+	})
+	t.Run("gzipped block", func(t *testing.T) {
+		v1alpha1Server := &testutil.MockValidatorServer{}
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(gzipBytes(t, []byte(phase0Block))))
+		request.Header.Set("Content-Encoding", "gzip")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, 1, len(v1alpha1Server.ProposedBlocks))
+	})
+	t.Run("JSON with version header", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_Capella)
+			return ok
+		}))
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(capellaBlock)))
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("JSON with mismatched version header", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(bellatrixBlock)))
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Body does not represent a valid capella block", writer.Body.String())
+	})
+	t.Run("JSON with null message", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		body := `{"message": null, "signature": "0x` + strings.Repeat("00", 96) + `"}`
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(body)))
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Body does not represent a valid capella block", writer.Body.String())
+	})
+	t.Run("JSON with unknown version header", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(capellaBlock)))
+		request.Header.Set(api.VersionHeader, "foo")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Could not parse Eth-Consensus-Version header", writer.Body.String())
+	})
+	t.Run("both execution payload and header", func(t *testing.T) {
+		server := &Server{
+			SyncChecker:         &mockSync.Sync{IsSyncing: false},
+			LenientJSONDecoding: true,
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(ambiguousCapellaBlock(t)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Ambiguous block: body contains both execution_payload and execution_payload_header", writer.Body.String())
+	})
+	t.Run("empty body", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(nil))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Empty request body", writer.Body.String())
+	})
+	t.Run("empty SSZ body", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(nil))
+		request.Header.Set("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Empty request body", writer.Body.String())
+	})
 	t.Run("invalid block", func(t *testing.T) {
 		server := &Server{
 			SyncChecker: &mockSync.Sync{IsSyncing: false},
@@ -111,6 +326,33 @@ func TestPublishBlockV2(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, writer.Code)
 		assert.Equal(t, true, strings.Contains(writer.Body.String(), "Body does not represent a valid block type"))
 	})
+	t.Run("body too large", func(t *testing.T) {
+		params.SetupTestConfigCleanup(t)
+		cfg := params.BeaconNetworkConfig().Copy()
+		cfg.GossipMaxSizeBellatrix = 50
+		params.OverrideBeaconNetworkConfig(cfg)
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(capellaBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, writer.Code)
+	})
+	t.Run("wrong method", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusMethodNotAllowed, writer.Code)
+		assert.Equal(t, http.MethodPost, writer.Header().Get("Allow"))
+	})
 	t.Run("syncing", func(t *testing.T) {
 		chainService := &testing2.ChainService{}
 		server := &Server{
@@ -130,6 +372,7 @@ func TestPublishBlockV2(t *testing.T) {
 }
 
 func TestPublishBlockV2SSZ(t *testing.T) {
+	activateForksAtGenesis(t)
 	ctrl := gomock.NewController(t)
 
 	t.Run("Bellatrix", func(t *testing.T) {
@@ -181,43 +424,48 @@ func TestPublishBlockV2SSZ(t *testing.T) {
 		server.PublishBlockV2(writer, request)
 		assert.Equal(t, http.StatusOK, writer.Code)
 	})
-	t.Run("invalid block", func(t *testing.T) {
+	t.Run("snappy", func(t *testing.T) {
+		v1alpha1Server := &testutil.MockValidatorServer{}
 		server := &Server{
-			SyncChecker: &mockSync.Sync{IsSyncing: false},
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
 		}
 
-		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(blindedBellatrixBlock)))
+		var cblock SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &cblock))
+		genericBlock, err := cblock.ToGeneric()
+		require.NoError(t, err)
+		signedBlk, err := blocks.NewSignedBeaconBlock(genericBlock.Block)
+		require.NoError(t, err)
+		// Let the handlers' own writer frame the block, so that this round-trips snappy framed SSZ.
+		framed := httptest.NewRecorder()
+		require.NoError(t, http2.WriteSszSnappyFrom(framed, signedBlk, "beacon_block.ssz_snappy"))
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(framed.Body.Bytes()))
+		request.Header.Set("Content-Type", "application/octet-stream+snappy")
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
 		server.PublishBlockV2(writer, request)
-		assert.Equal(t, http.StatusBadRequest, writer.Code)
-		assert.Equal(t, true, strings.Contains(writer.Body.String(), "Body does not represent a valid block type"))
+		require.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, 1, len(v1alpha1Server.ProposedBlocks))
+		assert.DeepSSZEqual(t, genericBlock.GetCapella(), v1alpha1Server.ProposedBlocks[0].GetCapella())
 	})
-}
-
-func TestPublishBlindedBlockV2(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	t.Run("Phase 0", func(t *testing.T) {
-		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
-		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
-			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_Phase0)
-			return ok
-		}))
+	t.Run("snappy invalid framing", func(t *testing.T) {
 		server := &Server{
-			V1Alpha1ValidatorServer: v1alpha1Server,
-			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
 		}
 
-		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(phase0Block)))
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example?encoding=ssz_snappy", bytes.NewReader([]byte("foo")))
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
-		server.PublishBlindedBlockV2(writer, request)
-		assert.Equal(t, http.StatusOK, writer.Code)
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Could not decode snappy framed request body", writer.Body.String())
 	})
-	t.Run("Altair", func(t *testing.T) {
+	t.Run("version header", func(t *testing.T) {
 		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
 		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
-			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_Altair)
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_Capella)
 			return ok
 		}))
 		server := &Server{
@@ -225,77 +473,498 @@ func TestPublishBlindedBlockV2(t *testing.T) {
 			SyncChecker:             &mockSync.Sync{IsSyncing: false},
 		}
 
-		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(altairBlock)))
+		var cblock SignedBeaconBlockCapella
+		err := json.Unmarshal([]byte(capellaBlock), &cblock)
+		require.NoError(t, err)
+		sszvalue, err := cblock.MarshalSSZ()
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
+		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
-		server.PublishBlindedBlockV2(writer, request)
+		server.PublishBlockV2(writer, request)
 		assert.Equal(t, http.StatusOK, writer.Code)
 	})
-	t.Run("Bellatrix", func(t *testing.T) {
-		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
-		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
-			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_BlindedBellatrix)
-			return ok
-		}))
+	t.Run("mismatched version header", func(t *testing.T) {
 		server := &Server{
-			V1Alpha1ValidatorServer: v1alpha1Server,
-			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
 		}
 
-		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(blindedBellatrixBlock)))
+		var bblock SignedBeaconBlockBellatrix
+		err := json.Unmarshal([]byte(bellatrixBlock), &bblock)
+		require.NoError(t, err)
+		sszvalue, err := bblock.MarshalSSZ()
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
+		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
-		server.PublishBlindedBlockV2(writer, request)
-		assert.Equal(t, http.StatusOK, writer.Code)
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Body does not represent a valid capella block", writer.Body.String())
 	})
-	t.Run("Capella", func(t *testing.T) {
-		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
-		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
-			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_BlindedCapella)
-			return ok
-		}))
+	t.Run("capella block with bellatrix version header", func(t *testing.T) {
 		server := &Server{
-			V1Alpha1ValidatorServer: v1alpha1Server,
-			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
 		}
 
-		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(blindedCapellaBlock)))
+		var cblock SignedBeaconBlockCapella
+		err := json.Unmarshal([]byte(capellaBlock), &cblock)
+		require.NoError(t, err)
+		sszvalue, err := cblock.MarshalSSZ()
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
+		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set(api.VersionHeader, version.String(version.Bellatrix))
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
-		server.PublishBlindedBlockV2(writer, request)
-		assert.Equal(t, http.StatusOK, writer.Code)
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Invalid Eth-Consensus-Version header: block at slot 1 belongs to the capella fork, not bellatrix", writer.Body.String())
 	})
-	t.Run("invalid block", func(t *testing.T) {
+	t.Run("version header of a fork not scheduled at the block's slot", func(t *testing.T) {
+		params.SetupTestConfigCleanup(t)
+		cfg := params.BeaconConfig().Copy()
+		cfg.CapellaForkEpoch = 1
+		params.OverrideBeaconConfig(cfg)
 		server := &Server{
 			SyncChecker: &mockSync.Sync{IsSyncing: false},
 		}
 
-		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(bellatrixBlock)))
+		// The block is a valid capella block, but its slot is still in the bellatrix fork.
+		var cblock SignedBeaconBlockCapella
+		err := json.Unmarshal([]byte(capellaBlock), &cblock)
+		require.NoError(t, err)
+		sszvalue, err := cblock.MarshalSSZ()
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
+		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
-		server.PublishBlindedBlockV2(writer, request)
+		server.PublishBlockV2(writer, request)
 		assert.Equal(t, http.StatusBadRequest, writer.Code)
-		assert.Equal(t, true, strings.Contains(writer.Body.String(), "Body does not represent a valid block type"))
+		assert.StringContains(t, "Invalid Eth-Consensus-Version header: block at slot 1 belongs to the bellatrix fork, not capella", writer.Body.String())
 	})
-	t.Run("syncing", func(t *testing.T) {
-		chainService := &testing2.ChainService{}
+	t.Run("unknown version header", func(t *testing.T) {
 		server := &Server{
-			SyncChecker:           &mockSync.Sync{IsSyncing: true},
-			HeadFetcher:           chainService,
-			TimeFetcher:           chainService,
-			OptimisticModeFetcher: chainService,
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
 		}
 
 		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte("foo")))
+		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set(api.VersionHeader, "foo")
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
-		server.PublishBlindedBlockV2(writer, request)
-		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
-		assert.Equal(t, true, strings.Contains(writer.Body.String(), "Beacon node is currently syncing and not serving request on that endpoint"))
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Could not parse Eth-Consensus-Version header", writer.Body.String())
 	})
-}
-
+	t.Run("body too large", func(t *testing.T) {
+		params.SetupTestConfigCleanup(t)
+		cfg := params.BeaconNetworkConfig().Copy()
+		cfg.GossipMaxSizeBellatrix = 50
+		params.OverrideBeaconNetworkConfig(cfg)
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(make([]byte, 101)))
+		request.Header.Set("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, writer.Code)
+	})
+	t.Run("invalid block", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(blindedBellatrixBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.Equal(t, true, strings.Contains(writer.Body.String(), "Body does not represent a valid block type"))
+	})
+}
+
+// benchmarkPublishedBlocks holds the JSON encoding of a block of each fork, in the order in which forks
+// are tried when decoding by trial.
+var benchmarkPublishedBlocks = []struct {
+	fork int
+	json string
+}{
+	{fork: version.Capella, json: capellaBlock},
+	{fork: version.Bellatrix, json: bellatrixBlock},
+	{fork: version.Altair, json: altairBlock},
+	{fork: version.Phase0, json: phase0Block},
+}
+
+// benchmarkPublishServer returns a server whose v1alpha1 server accepts every proposed block, so that only
+// the decoding and conversion of the published block are measured.
+func benchmarkPublishServer(b *testing.B) *Server {
+	ctrl := gomock.NewController(b)
+	v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+	v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), gomock.Any()).AnyTimes()
+	return &Server{
+		V1Alpha1ValidatorServer: v1alpha1Server,
+		SyncChecker:             &mockSync.Sync{IsSyncing: false},
+	}
+}
+
+// activateForkAtGenesis schedules the given fork, and every fork before it, at genesis. Later forks
+// are never activated, so that the blocks of the given fork are the ones expected at any slot.
+func activateForkAtGenesis(b *testing.B, fork int) {
+	params.SetupTestConfigCleanup(b)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = cfg.FarFutureEpoch
+	cfg.BellatrixForkEpoch = cfg.FarFutureEpoch
+	cfg.CapellaForkEpoch = cfg.FarFutureEpoch
+	if fork >= version.Altair {
+		cfg.AltairForkEpoch = 0
+	}
+	if fork >= version.Bellatrix {
+		cfg.BellatrixForkEpoch = 0
+	}
+	if fork >= version.Capella {
+		cfg.CapellaForkEpoch = 0
+	}
+	params.OverrideBeaconConfig(cfg)
+}
+
+// BenchmarkPublishBlockV2 measures publishing a JSON block of each fork, with and without the fork being
+// declared in the request header. Without the header, older forks pay for the failed attempts to decode
+// the block as a block of each newer fork.
+func BenchmarkPublishBlockV2(b *testing.B) {
+	server := benchmarkPublishServer(b)
+	for _, blk := range benchmarkPublishedBlocks {
+		activateForkAtGenesis(b, blk.fork)
+		body := []byte(blk.json)
+		forkName := version.String(blk.fork)
+		b.Run(forkName+"/trial decoding", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(body))
+				writer := httptest.NewRecorder()
+				server.PublishBlockV2(writer, request)
+				require.Equal(b, http.StatusOK, writer.Code)
+			}
+		})
+		b.Run(forkName+"/version header", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(body))
+				request.Header.Set(api.VersionHeader, forkName)
+				writer := httptest.NewRecorder()
+				server.PublishBlockV2(writer, request)
+				require.Equal(b, http.StatusOK, writer.Code)
+			}
+		})
+	}
+}
+
+// BenchmarkPublishBlockV2SSZ measures publishing an SSZ block of each fork, with and without the fork being
+// declared in the request header. Without the header, older forks pay for the failed attempts to decode
+// the block as a block of each newer fork.
+func BenchmarkPublishBlockV2SSZ(b *testing.B) {
+	server := benchmarkPublishServer(b)
+	for _, blk := range benchmarkPublishedBlocks {
+		activateForkAtGenesis(b, blk.fork)
+		decode := jsonBlockDecoders(jsonBlockParsers, json.Unmarshal)[blk.fork]
+		genericBlock, err := decode([]byte(blk.json))
+		require.NoError(b, err)
+		signedBlk, err := blocks.NewSignedBeaconBlock(genericBlock.Block)
+		require.NoError(b, err)
+		body, err := signedBlk.MarshalSSZ()
+		require.NoError(b, err)
+		forkName := version.String(blk.fork)
+		b.Run(forkName+"/trial decoding", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(body))
+				request.Header.Set("Accept", "application/octet-stream")
+				writer := httptest.NewRecorder()
+				server.PublishBlockV2(writer, request)
+				require.Equal(b, http.StatusOK, writer.Code)
+			}
+		})
+		b.Run(forkName+"/version header", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(body))
+				request.Header.Set("Accept", "application/octet-stream")
+				request.Header.Set(api.VersionHeader, forkName)
+				writer := httptest.NewRecorder()
+				server.PublishBlockV2(writer, request)
+				require.Equal(b, http.StatusOK, writer.Code)
+			}
+		})
+	}
+}
+
+func TestPublishBlindedBlockV2(t *testing.T) {
+	activateForksAtGenesis(t)
+	ctrl := gomock.NewController(t)
+	t.Run("Phase 0", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_Phase0)
+			return ok
+		}))
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(phase0Block)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("Altair", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_Altair)
+			return ok
+		}))
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(altairBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("Bellatrix", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_BlindedBellatrix)
+			return ok
+		}))
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(blindedBellatrixBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("Capella", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_BlindedCapella)
+			return ok
+		}))
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(blindedCapellaBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("JSON with version header", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_BlindedBellatrix)
+			return ok
+		})).Times(2)
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		publish := func(versionHeader string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(blindedBellatrixBlock)))
+			if versionHeader != "" {
+				request.Header.Set(api.VersionHeader, versionHeader)
+			}
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			server.PublishBlindedBlockV2(writer, request)
+			return writer
+		}
+
+		writer := publish(version.String(version.Bellatrix))
+		assert.Equal(t, http.StatusOK, writer.Code)
+		writer = publish(version.String(version.Capella))
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Body does not represent a valid capella block", writer.Body.String())
+		// Without the header, each fork is tried.
+		writer = publish("")
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("response contains block root", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_BlindedCapella)
+			return ok
+		}))
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		var blk SignedBlindedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(blindedCapellaBlock), &blk))
+		genericBlk, err := blk.ToGeneric()
+		require.NoError(t, err)
+		signedBlk, err := blocks.NewSignedBeaconBlock(genericBlk.Block)
+		require.NoError(t, err)
+		expectedRoot, err := signedBlk.Block().HashTreeRoot()
+		require.NoError(t, err)
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(blindedCapellaBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &PublishBlockResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		assert.Equal(t, blk.Message.Slot, resp.Data.Slot)
+		assert.Equal(t, hexutil.Encode(expectedRoot[:]), resp.Data.Root)
+	})
+	t.Run("builder payload mismatch", func(t *testing.T) {
+		server := &Server{
+			V1Alpha1ValidatorServer: &testutil.MockValidatorServer{
+				ErrorToReturn: errors.Wrap(v1alpha1validator.ErrPayloadMismatch, "withdrawals root 0x01 does not match header withdrawals root 0x02"),
+			},
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(blindedCapellaBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "withdrawals root", writer.Body.String())
+	})
+	t.Run("both execution payload and header", func(t *testing.T) {
+		server := &Server{
+			SyncChecker:         &mockSync.Sync{IsSyncing: false},
+			LenientJSONDecoding: true,
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(ambiguousCapellaBlock(t)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Ambiguous block: body contains both execution_payload and execution_payload_header", writer.Body.String())
+	})
+	t.Run("empty body", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(nil))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Empty request body", writer.Body.String())
+	})
+	t.Run("empty SSZ body", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(nil))
+		request.Header.Set("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Empty request body", writer.Body.String())
+	})
+	t.Run("invalid block", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(bellatrixBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.Equal(t, true, strings.Contains(writer.Body.String(), "Body does not represent a valid block type"))
+	})
+	t.Run("body too large", func(t *testing.T) {
+		params.SetupTestConfigCleanup(t)
+		cfg := params.BeaconNetworkConfig().Copy()
+		cfg.GossipMaxSizeBellatrix = 50
+		params.OverrideBeaconNetworkConfig(cfg)
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(capellaBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, writer.Code)
+	})
+	t.Run("wrong method", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusMethodNotAllowed, writer.Code)
+		assert.Equal(t, http.MethodPost, writer.Header().Get("Allow"))
+	})
+	t.Run("syncing", func(t *testing.T) {
+		chainService := &testing2.ChainService{}
+		server := &Server{
+			SyncChecker:           &mockSync.Sync{IsSyncing: true},
+			HeadFetcher:           chainService,
+			TimeFetcher:           chainService,
+			OptimisticModeFetcher: chainService,
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte("foo")))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+		assert.Equal(t, true, strings.Contains(writer.Body.String(), "Beacon node is currently syncing and not serving request on that endpoint"))
+	})
+}
+
+// ambiguousCapellaBlock returns a Capella block whose body contains both a full execution payload
+// and an execution payload header.
+func ambiguousCapellaBlock(t *testing.T) []byte {
+	var full, blinded map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(capellaBlock), &full))
+	require.NoError(t, json.Unmarshal([]byte(blindedCapellaBlock), &blinded))
+	fullBody := full["message"].(map[string]interface{})["body"].(map[string]interface{})
+	blindedBody := blinded["message"].(map[string]interface{})["body"].(map[string]interface{})
+	fullBody["execution_payload_header"] = blindedBody["execution_payload_header"]
+	body, err := json.Marshal(full)
+	require.NoError(t, err)
+	return body
+}
+
 func TestPublishBlindedBlockV2SSZ(t *testing.T) {
+	activateForksAtGenesis(t)
 	ctrl := gomock.NewController(t)
 	t.Run("Bellatrix", func(t *testing.T) {
 		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
@@ -322,67 +991,1312 @@ func TestPublishBlindedBlockV2SSZ(t *testing.T) {
 		server.PublishBlindedBlockV2(writer, request)
 		assert.Equal(t, http.StatusOK, writer.Code)
 	})
-	t.Run("Capella", func(t *testing.T) {
+	t.Run("Capella", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_BlindedCapella)
+			return ok
+		}))
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		var cblock SignedBlindedBeaconBlockCapella
+		err := json.Unmarshal([]byte(blindedCapellaBlock), &cblock)
+		require.NoError(t, err)
+		genericBlock, err := cblock.ToGeneric()
+		require.NoError(t, err)
+		sszvalue, err := genericBlock.GetBlindedCapella().MarshalSSZ()
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
+		request.Header.Set("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("version header", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_BlindedCapella)
+			return ok
+		}))
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		var cblock SignedBlindedBeaconBlockCapella
+		err := json.Unmarshal([]byte(blindedCapellaBlock), &cblock)
+		require.NoError(t, err)
+		sszvalue, err := cblock.MarshalSSZ()
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
+		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("mismatched version header", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		var bblock SignedBlindedBeaconBlockBellatrix
+		err := json.Unmarshal([]byte(blindedBellatrixBlock), &bblock)
+		require.NoError(t, err)
+		sszvalue, err := bblock.MarshalSSZ()
+		require.NoError(t, err)
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
+		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Body does not represent a valid capella block", writer.Body.String())
+	})
+	t.Run("unknown version header", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte("foo")))
+		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set(api.VersionHeader, "foo")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Could not parse Eth-Consensus-Version header", writer.Body.String())
+	})
+	t.Run("body too large", func(t *testing.T) {
+		params.SetupTestConfigCleanup(t)
+		cfg := params.BeaconNetworkConfig().Copy()
+		cfg.GossipMaxSizeBellatrix = 50
+		params.OverrideBeaconNetworkConfig(cfg)
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(make([]byte, 101)))
+		request.Header.Set("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, writer.Code)
+	})
+	t.Run("invalid block", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(bellatrixBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.Equal(t, true, strings.Contains(writer.Body.String(), "Body does not represent a valid block type"))
+	})
+}
+
+func TestPublishBlock_BlockContents(t *testing.T) {
+	activateForksAtGenesis(t)
+	contents := `{"signed_block": ` + capellaBlock + `, "signed_blob_sidecars": []}`
+	blindedContents := `{"signed_blinded_block": ` + blindedCapellaBlock + `, "signed_blinded_blob_sidecars": []}`
+
+	tests := []struct {
+		name     string
+		body     string
+		handler  func(s *Server) http.HandlerFunc
+		expected string
+	}{
+		{
+			name:     "block contents to block endpoint",
+			body:     contents,
+			handler:  func(s *Server) http.HandlerFunc { return s.PublishBlockV2 },
+			expected: "submit the block in signed_block on its own",
+		},
+		{
+			name:     "blinded block contents to block endpoint",
+			body:     blindedContents,
+			handler:  func(s *Server) http.HandlerFunc { return s.PublishBlockV2 },
+			expected: "submit the block in signed_blinded_block on its own",
+		},
+		{
+			name:     "block contents to blinded block endpoint",
+			body:     contents,
+			handler:  func(s *Server) http.HandlerFunc { return s.PublishBlindedBlockV2 },
+			expected: "submit the block in signed_block on its own",
+		},
+		{
+			name:     "blinded block contents to blinded block endpoint",
+			body:     blindedContents,
+			handler:  func(s *Server) http.HandlerFunc { return s.PublishBlindedBlockV2 },
+			expected: "submit the block in signed_blinded_block on its own",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1alpha1Server := &testutil.MockValidatorServer{}
+			server := &Server{
+				V1Alpha1ValidatorServer: v1alpha1Server,
+				SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(tt.body)))
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			tt.handler(server)(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code)
+			assert.StringContains(t, "Block contents are not supported", writer.Body.String())
+			assert.StringContains(t, tt.expected, writer.Body.String())
+			assert.Equal(t, 0, len(v1alpha1Server.ProposedBlocks))
+		})
+	}
+	t.Run("block contents with version header", func(t *testing.T) {
+		server := &Server{
+			V1Alpha1ValidatorServer: &testutil.MockValidatorServer{},
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(contents)))
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Block contents are not supported", writer.Body.String())
+	})
+}
+
+func TestValidateNotBlockContents(t *testing.T) {
+	require.NoError(t, validateNotBlockContents([]byte(capellaBlock)))
+	require.NoError(t, validateNotBlockContents([]byte(blindedCapellaBlock)))
+	require.NoError(t, validateNotBlockContents([]byte("[]")))
+	// A wrapped block without sidecars is left for the block decoders to report.
+	require.NoError(t, validateNotBlockContents([]byte(`{"signed_block": `+capellaBlock+`}`)))
+	require.ErrorContains(t, "signed_block", validateNotBlockContents([]byte(`{"signed_block": {}, "signed_blob_sidecars": []}`)))
+	require.ErrorContains(t, "signed_blinded_block", validateNotBlockContents([]byte(`{"signed_blinded_block": {}, "signed_blinded_blob_sidecars": []}`)))
+}
+
+func TestPublishBlock_AcceptedForks(t *testing.T) {
+	activateForksAtGenesis(t)
+	var capellaJson SignedBeaconBlockCapella
+	require.NoError(t, json.Unmarshal([]byte(capellaBlock), &capellaJson))
+	capellaSSZ, err := capellaJson.MarshalSSZ()
+	require.NoError(t, err)
+
+	t.Run("fork accepted", func(t *testing.T) {
+		v1alpha1Server := &testutil.MockValidatorServer{}
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			AcceptedForks:           map[int]bool{version.Capella: true},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(capellaBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, 1, len(v1alpha1Server.ProposedBlocks))
+	})
+	t.Run("fork not accepted", func(t *testing.T) {
+		v1alpha1Server := &testutil.MockValidatorServer{}
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			AcceptedForks:           map[int]bool{version.Bellatrix: true},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(capellaBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Publishing capella blocks is not allowed", writer.Body.String())
+		assert.Equal(t, 0, len(v1alpha1Server.ProposedBlocks))
+	})
+	t.Run("blinded fork not accepted", func(t *testing.T) {
+		server := &Server{
+			V1Alpha1ValidatorServer: &testutil.MockValidatorServer{},
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			AcceptedForks:           map[int]bool{version.Capella: true},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(blindedBellatrixBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlindedBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Publishing bellatrix blocks is not allowed", writer.Body.String())
+	})
+	t.Run("SSZ fork not accepted", func(t *testing.T) {
+		server := &Server{
+			V1Alpha1ValidatorServer: &testutil.MockValidatorServer{},
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			AcceptedForks:           map[int]bool{version.Bellatrix: true},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(capellaSSZ))
+		request.Header.Set("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Publishing capella blocks is not allowed", writer.Body.String())
+	})
+	t.Run("SSZ version header fork not accepted", func(t *testing.T) {
+		server := &Server{
+			V1Alpha1ValidatorServer: &testutil.MockValidatorServer{},
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			AcceptedForks:           map[int]bool{version.Bellatrix: true},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(capellaSSZ))
+		request.Header.Set("Accept", "application/octet-stream")
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Publishing capella blocks is not allowed", writer.Body.String())
+	})
+}
+
+func TestPublishBlock_SSZOnly(t *testing.T) {
+	activateForksAtGenesis(t)
+	var capellaJson SignedBeaconBlockCapella
+	require.NoError(t, json.Unmarshal([]byte(capellaBlock), &capellaJson))
+	capellaSSZ, err := capellaJson.MarshalSSZ()
+	require.NoError(t, err)
+
+	t.Run("JSON accepted by default", func(t *testing.T) {
+		v1alpha1Server := &testutil.MockValidatorServer{}
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(capellaBlock)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, 1, len(v1alpha1Server.ProposedBlocks))
+	})
+	t.Run("SSZ accepted", func(t *testing.T) {
+		v1alpha1Server := &testutil.MockValidatorServer{}
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			SSZOnly:                 true,
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(capellaSSZ))
+		request.Header.Set("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, 1, len(v1alpha1Server.ProposedBlocks))
+	})
+
+	handlers := map[string]struct {
+		handler func(s *Server) http.HandlerFunc
+		body    string
+	}{
+		"PublishBlockV2": {
+			handler: func(s *Server) http.HandlerFunc { return s.PublishBlockV2 },
+			body:    capellaBlock,
+		},
+		"PublishBlindedBlockV2": {
+			handler: func(s *Server) http.HandlerFunc { return s.PublishBlindedBlockV2 },
+			body:    blindedCapellaBlock,
+		},
+		"PublishBlockWithDetachedSignature": {
+			handler: func(s *Server) http.HandlerFunc { return s.PublishBlockWithDetachedSignature },
+			body:    `{"block": {}, "signature": "0x"}`,
+		},
+		"PublishBlocks": {
+			handler: func(s *Server) http.HandlerFunc { return s.PublishBlocks },
+			body:    "[" + capellaBlock + "]",
+		},
+	}
+	for name, tc := range handlers {
+		t.Run(name+" JSON rejected", func(t *testing.T) {
+			v1alpha1Server := &testutil.MockValidatorServer{}
+			server := &Server{
+				V1Alpha1ValidatorServer: v1alpha1Server,
+				SyncChecker:             &mockSync.Sync{IsSyncing: false},
+				SSZOnly:                 true,
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(tc.body)))
+			request.Header.Set(api.VersionHeader, version.String(version.Capella))
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			tc.handler(server)(writer, request)
+			assert.Equal(t, http.StatusUnsupportedMediaType, writer.Code)
+			assert.StringContains(t, "Publishing JSON blocks is disabled", writer.Body.String())
+			assert.Equal(t, 0, len(v1alpha1Server.ProposedBlocks))
+		})
+	}
+}
+
+func TestReadBody(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconNetworkConfig().Copy()
+	cfg.GossipMaxSizeBellatrix = 50
+	params.OverrideBeaconNetworkConfig(cfg)
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte("foo")))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		body, ok := readBody(writer, request)
+		require.Equal(t, true, ok)
+		assert.DeepEqual(t, []byte("foo"), body)
+	})
+	t.Run("empty", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(nil))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		_, ok := readBody(writer, request)
+		require.Equal(t, false, ok)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Empty request body", writer.Body.String())
+	})
+	t.Run("declared length too large", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(make([]byte, 101)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		_, ok := readBody(writer, request)
+		require.Equal(t, false, ok)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, writer.Code)
+		assert.StringContains(t, "Request body size 101 exceeds the maximum allowed size of 100 bytes", writer.Body.String())
+	})
+	t.Run("chunked body too large", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(make([]byte, 101)))
+		// Clients using chunked transfer encoding don't declare the body length.
+		request.ContentLength = -1
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		_, ok := readBody(writer, request)
+		require.Equal(t, false, ok)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, writer.Code)
+		assert.StringContains(t, "Request body exceeds the maximum allowed size of 100 bytes", writer.Body.String())
+	})
+	t.Run("incomplete chunked body", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", io.MultiReader(bytes.NewReader([]byte("foo")), iotest.ErrReader(io.ErrUnexpectedEOF)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		_, ok := readBody(writer, request)
+		require.Equal(t, false, ok)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Request body is incomplete", writer.Body.String())
+	})
+	t.Run("gzip", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(gzipBytes(t, []byte("foo"))))
+		request.Header.Set("Content-Encoding", "gzip")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		body, ok := readBody(writer, request)
+		require.Equal(t, true, ok)
+		assert.DeepEqual(t, []byte("foo"), body)
+	})
+	t.Run("deflate", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		_, err := zw.Write([]byte("foo"))
+		require.NoError(t, err)
+		require.NoError(t, zw.Close())
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", &buf)
+		request.Header.Set("Content-Encoding", "deflate")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		body, ok := readBody(writer, request)
+		require.Equal(t, true, ok)
+		assert.DeepEqual(t, []byte("foo"), body)
+	})
+	t.Run("corrupt compressed body", func(t *testing.T) {
+		compressed := gzipBytes(t, []byte("foo"))
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(compressed[:len(compressed)-4]))
+		request.Header.Set("Content-Encoding", "gzip")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		_, ok := readBody(writer, request)
+		require.Equal(t, false, ok)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Could not decompress request body", writer.Body.String())
+	})
+	t.Run("decompressed body too large", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(gzipBytes(t, make([]byte, 1000))))
+		request.Header.Set("Content-Encoding", "gzip")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		_, ok := readBody(writer, request)
+		require.Equal(t, false, ok)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, writer.Code)
+		assert.StringContains(t, "Decompressed request body exceeds the maximum allowed size of 100 bytes", writer.Body.String())
+	})
+	t.Run("unsupported encoding", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte("foo")))
+		request.Header.Set("Content-Encoding", "br")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		_, ok := readBody(writer, request)
+		require.Equal(t, false, ok)
+		assert.Equal(t, http.StatusUnsupportedMediaType, writer.Code)
+	})
+}
+
+// TestPublishBlockV2_ChunkedBodyTooLarge posts oversized bodies with chunked transfer encoding through
+// a real HTTP server, so that no Content-Length is available to reject them up front.
+func TestPublishBlockV2_ChunkedBodyTooLarge(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconNetworkConfig().Copy()
+	cfg.GossipMaxSizeBellatrix = 50
+	params.OverrideBeaconNetworkConfig(cfg)
+	server := &Server{
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+	}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    []byte
+		ssz     bool
+	}{
+		{name: "block JSON", handler: server.PublishBlockV2, body: []byte(capellaBlock)},
+		{name: "block SSZ", handler: server.PublishBlockV2, body: make([]byte, 1000), ssz: true},
+		{name: "blinded block JSON", handler: server.PublishBlindedBlockV2, body: []byte(blindedCapellaBlock)},
+		{name: "blinded block SSZ", handler: server.PublishBlindedBlockV2, body: make([]byte, 1000), ssz: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+
+			// A reader of unknown length makes the client use chunked transfer encoding.
+			request, err := http.NewRequest(http.MethodPost, srv.URL, io.MultiReader(bytes.NewReader(tt.body)))
+			require.NoError(t, err)
+			if tt.ssz {
+				request.Header.Set("Accept", "application/octet-stream")
+			}
+			resp, err := srv.Client().Do(request)
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, resp.Body.Close())
+			}()
+			assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+			respBody, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			assert.StringContains(t, "Request body exceeds the maximum allowed size of 100 bytes", string(respBody))
+		})
+	}
+}
+
+func TestWriteErr(t *testing.T) {
+	t.Run("writeErr", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		writeErr(writer, http.StatusNotFound, "foo")
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusNotFound, e.Code)
+		assert.Equal(t, "foo", e.Message)
+	})
+	t.Run("writeErrf", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		writeErrf(writer, http.StatusBadRequest, "foo %d %s", 1, "bar")
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &http2.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusBadRequest, e.Code)
+		assert.Equal(t, "foo 1 bar", e.Message)
+	})
+}
+
+func gzipBytes(t *testing.T, b []byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(b)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestUnmarshalSSZStrict(t *testing.T) {
+	var cblock SignedBeaconBlockCapella
+	require.NoError(t, json.Unmarshal([]byte(capellaBlock), &cblock))
+	sszvalue, err := cblock.MarshalSSZ()
+	require.NoError(t, err)
+
+	t.Run("ok", func(t *testing.T) {
+		require.NoError(t, unmarshalSSZStrict(sszvalue, &ethpbv2.SignedBeaconBlockCapella{}))
+	})
+	t.Run("newer fork body", func(t *testing.T) {
+		// Plain decoding accepts the Capella bytes as a Bellatrix block.
+		require.NoError(t, (&ethpbv2.SignedBeaconBlockBellatrix{}).UnmarshalSSZ(sszvalue))
+		err := unmarshalSSZStrict(sszvalue, &ethpbv2.SignedBeaconBlockBellatrix{})
+		assert.ErrorContains(t, "decoded block does not re-encode to the request body", err)
+	})
+	t.Run("invalid body", func(t *testing.T) {
+		err := unmarshalSSZStrict([]byte("foo"), &ethpbv2.SignedBeaconBlockCapella{})
+		assert.NotNil(t, err)
+	})
+}
+
+func TestValidateConsensus(t *testing.T) {
+	ctx := context.Background()
+
+	parentState, privs := util.DeterministicGenesisState(t, params.MinimalSpecConfig().MinGenesisActiveValidatorCount)
+	parentBlock, err := util.GenerateFullBlock(parentState, privs, util.DefaultBlockGenConfig(), parentState.Slot())
+	require.NoError(t, err)
+	parentSbb, err := blocks.NewSignedBeaconBlock(parentBlock)
+	require.NoError(t, err)
+	st, err := transition.ExecuteStateTransition(ctx, parentState, parentSbb)
+	require.NoError(t, err)
+	block, err := util.GenerateFullBlock(st, privs, util.DefaultBlockGenConfig(), st.Slot())
+	require.NoError(t, err)
+	sbb, err := blocks.NewSignedBeaconBlock(block)
+	require.NoError(t, err)
+	parentRoot, err := parentSbb.Block().HashTreeRoot()
+	require.NoError(t, err)
+	currentSlot := sbb.Block().Slot()
+	// The state transition advances the parent state in place, so validations after the first need their own copy.
+	parentStateCopy := parentState.Copy()
+	server := &Server{
+		Blocker:             &testutil.MockBlocker{RootBlockMap: map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{parentRoot: parentSbb}},
+		Stater:              &testutil.MockStater{StatesByRoot: map[[32]byte]state.BeaconState{bytesutil.ToBytes32(parentBlock.Block.StateRoot): parentState}},
+		FinalizationFetcher: &testing2.ChainService{FinalizedCheckPoint: &eth.Checkpoint{}},
+		TimeFetcher:         &testing2.ChainService{Slot: &currentSlot},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		require.NoError(t, server.validateConsensus(ctx, sbb))
+	})
+	t.Run("block from the future", func(t *testing.T) {
+		previousSlot := currentSlot - 1
+		server := &Server{
+			FinalizationFetcher: &testing2.ChainService{FinalizedCheckPoint: &eth.Checkpoint{}},
+			TimeFetcher:         &testing2.ChainService{Slot: &previousSlot},
+		}
+		err := server.validateConsensus(ctx, sbb)
+		assert.ErrorContains(t, fmt.Sprintf("block slot %d is in the future, the current slot is %d", currentSlot, previousSlot), err)
+	})
+	t.Run("block within clock disparity", func(t *testing.T) {
+		// The block's slot starts 3 seconds from now.
+		previousSlot := currentSlot - 1
+		genesis := time.Unix(1000, 0)
+		slotStart := genesis.Add(time.Duration(uint64(currentSlot)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+		now := slotStart.Add(-3 * time.Second)
+		server := &Server{
+			Blocker:                 server.Blocker,
+			Stater:                  &testutil.MockStater{StatesByRoot: map[[32]byte]state.BeaconState{bytesutil.ToBytes32(parentBlock.Block.StateRoot): parentStateCopy}},
+			FinalizationFetcher:     &testing2.ChainService{FinalizedCheckPoint: &eth.Checkpoint{}},
+			TimeFetcher:             &testing2.ChainService{Slot: &previousSlot, Genesis: genesis},
+			ClockDisparityTolerance: 3 * time.Second,
+			now:                     func() time.Time { return now },
+		}
+		require.NoError(t, server.validateConsensus(ctx, sbb))
+	})
+	t.Run("block beyond clock disparity", func(t *testing.T) {
+		previousSlot := currentSlot - 1
+		genesis := time.Unix(1000, 0)
+		slotStart := genesis.Add(time.Duration(uint64(currentSlot)*params.BeaconConfig().SecondsPerSlot) * time.Second)
+		now := slotStart.Add(-3 * time.Second)
+		server := &Server{
+			FinalizationFetcher:     &testing2.ChainService{FinalizedCheckPoint: &eth.Checkpoint{}},
+			TimeFetcher:             &testing2.ChainService{Slot: &previousSlot, Genesis: genesis},
+			ClockDisparityTolerance: 3*time.Second - time.Nanosecond,
+			now:                     func() time.Time { return now },
+		}
+		err := server.validateConsensus(ctx, sbb)
+		assert.ErrorContains(t, fmt.Sprintf("block slot %d is in the future, the current slot is %d", currentSlot, previousSlot), err)
+	})
+	t.Run("block before finalized slot", func(t *testing.T) {
+		server := &Server{
+			FinalizationFetcher: &testing2.ChainService{FinalizedCheckPoint: &eth.Checkpoint{Epoch: 1}},
+		}
+		err := server.validateConsensus(ctx, sbb)
+		assert.ErrorContains(t, fmt.Sprintf("block slot %d is before the finalized slot %d", sbb.Block().Slot(), params.BeaconConfig().SlotsPerEpoch), err)
+	})
+}
+
+func TestPublishBlockV2_ConsensusValidationFailure(t *testing.T) {
+	ctx := context.Background()
+
+	parentState, privs := util.DeterministicGenesisState(t, params.MinimalSpecConfig().MinGenesisActiveValidatorCount)
+	parentBlock, err := util.GenerateFullBlock(parentState, privs, util.DefaultBlockGenConfig(), parentState.Slot())
+	require.NoError(t, err)
+	parentSbb, err := blocks.NewSignedBeaconBlock(parentBlock)
+	require.NoError(t, err)
+	st, err := transition.ExecuteStateTransition(ctx, parentState.Copy(), parentSbb)
+	require.NoError(t, err)
+	block, err := util.GenerateFullBlock(st, privs, util.DefaultBlockGenConfig(), st.Slot())
+	require.NoError(t, err)
+	block.Block.StateRoot = bytesutil.PadTo([]byte("foo"), 32)
+	sszvalue, err := block.MarshalSSZ()
+	require.NoError(t, err)
+	parentRoot, err := parentSbb.Block().HashTreeRoot()
+	require.NoError(t, err)
+	currentSlot := block.Block.Slot
+	server := &Server{
+		SyncChecker:         &mockSync.Sync{IsSyncing: false},
+		Blocker:             &testutil.MockBlocker{RootBlockMap: map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{parentRoot: parentSbb}},
+		Stater:              &testutil.MockStater{StatesByRoot: map[[32]byte]state.BeaconState{bytesutil.ToBytes32(parentBlock.Block.StateRoot): st}},
+		FinalizationFetcher: &testing2.ChainService{FinalizedCheckPoint: &eth.Checkpoint{}},
+		TimeFetcher:         &testing2.ChainService{Slot: &currentSlot},
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "http://foo.example?broadcast_validation=consensus", bytes.NewReader(sszvalue))
+	request.Header.Set("Accept", "application/octet-stream")
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	server.PublishBlockV2(writer, request)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	resp := &ConsensusValidationErrorJson{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.StringContains(t, "consensus validation failed", resp.Message)
+	require.NotNil(t, resp.Failure)
+	assert.Equal(t, consensusFailureStateRoot, resp.Failure.Reason)
+	assert.StringContains(t, "could not validate state root", resp.Failure.Message)
+}
+
+func TestConsensusFailureReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+	}{
+		{
+			name:   "state root",
+			err:    errors.Wrap(errors.Wrap(errors.New("could not validate state root, wanted: 0x01, received: 0x02"), "could not execute state transition"), "could not execute state transition"),
+			reason: consensusFailureStateRoot,
+		},
+		{
+			name:   "signature",
+			err:    errors.Wrap(errors.New("signature in block failed to verify"), "could not execute state transition"),
+			reason: consensusFailureSignature,
+		},
+		{
+			name:   "batch signature",
+			err:    errors.Wrap(errors.Wrap(errors.New("foo"), "could not batch verify signature"), "could not execute state transition"),
+			reason: consensusFailureSignature,
+		},
+		{
+			name:   "attestation",
+			err:    errors.Wrap(errors.Wrap(errors.New("foo"), "could not process block attestations"), "could not process block operation"),
+			reason: consensusFailureAttestation,
+		},
+		{
+			name:   "altair attestation",
+			err:    errors.Wrap(errors.Wrap(errors.New("foo"), "could not process altair attestation"), "could not process block operation"),
+			reason: consensusFailureAttestation,
+		},
+		{
+			name:   "committee index",
+			err:    errors.Wrap(errCommitteeIndexOutOfRange, "attestation 0 has committee index 1 but slot 1 only has 1 committees"),
+			reason: consensusFailureAttestation,
+		},
+		{
+			name:   "attester slashing",
+			err:    errors.Wrap(errors.Wrap(errors.New("nil attestation"), "could not process block attester slashings"), "could not process block operation"),
+			reason: consensusFailureBlock,
+		},
+		{
+			name:   "block",
+			err:    errors.New("block slot 1 is before the finalized slot 32"),
+			reason: consensusFailureBlock,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.reason, consensusFailureReason(tt.err))
+		})
+	}
+}
+
+func TestPublishBlockV2_ConsensusValidationLimit(t *testing.T) {
+	currentSlot := primitives.Slot(1)
+	server := &Server{
+		SyncChecker:                       &mockSync.Sync{IsSyncing: false},
+		FinalizationFetcher:               &testing2.ChainService{FinalizedCheckPoint: &eth.Checkpoint{}},
+		TimeFetcher:                       &testing2.ChainService{Slot: &currentSlot},
+		MaxConcurrentConsensusValidations: 1,
+	}
+	release, ok := server.acquireConsensusValidation()
+	require.Equal(t, true, ok)
+	_, ok = server.acquireConsensusValidation()
+	require.Equal(t, false, ok)
+
+	request := httptest.NewRequest(http.MethodPost, "http://foo.example?broadcast_validation=consensus", bytes.NewReader([]byte(phase0Block)))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	server.PublishBlockV2(writer, request)
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	assert.Equal(t, "1", writer.Header().Get("Retry-After"))
+	assert.StringContains(t, errTooManyConsensusValidations.Error(), writer.Body.String())
+
+	release()
+	release, ok = server.acquireConsensusValidation()
+	require.Equal(t, true, ok)
+	release()
+}
+
+func TestValidateSignature(t *testing.T) {
+	ctx := context.Background()
+
+	parentState, privs := util.DeterministicGenesisState(t, params.MinimalSpecConfig().MinGenesisActiveValidatorCount)
+	parentBlock, err := util.GenerateFullBlock(parentState, privs, util.DefaultBlockGenConfig(), parentState.Slot())
+	require.NoError(t, err)
+	parentSbb, err := blocks.NewSignedBeaconBlock(parentBlock)
+	require.NoError(t, err)
+	st, err := transition.ExecuteStateTransition(ctx, parentState, parentSbb)
+	require.NoError(t, err)
+	block, err := util.GenerateFullBlock(st, privs, util.DefaultBlockGenConfig(), st.Slot())
+	require.NoError(t, err)
+	parentRoot, err := parentSbb.Block().HashTreeRoot()
+	require.NoError(t, err)
+	server := &Server{
+		Blocker: &testutil.MockBlocker{RootBlockMap: map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{parentRoot: parentSbb}},
+		Stater:  &testutil.MockStater{StatesByRoot: map[[32]byte]state.BeaconState{bytesutil.ToBytes32(parentBlock.Block.StateRoot): parentState}},
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		sbb, err := blocks.NewSignedBeaconBlock(block)
+		require.NoError(t, err)
+		require.NoError(t, server.validateSignature(ctx, sbb))
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		invalid := eth.CopySignedBeaconBlock(block)
+		invalid.Signature = privs[block.Block.ProposerIndex].Sign([]byte("foo")).Marshal()
+		sbb, err := blocks.NewSignedBeaconBlock(invalid)
+		require.NoError(t, err)
+		assert.ErrorContains(t, "could not verify block signature", server.validateSignature(ctx, sbb))
+	})
+}
+
+func TestValidateAmounts(t *testing.T) {
+	newDepositBlock := func(t *testing.T, amount uint64) interfaces.ReadOnlyBeaconBlock {
+		b := util.NewBeaconBlock()
+		b.Block.Body.Deposits = []*eth.Deposit{{Data: &eth.Deposit_Data{Amount: amount}}}
+		blk, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		return blk.Block()
+	}
+	newWithdrawalBlock := func(t *testing.T, amount uint64) interfaces.ReadOnlyBeaconBlock {
+		b := util.NewBeaconBlockCapella()
+		b.Block.Body.ExecutionPayload.Withdrawals = []*enginev1.Withdrawal{{Amount: amount}}
+		blk, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		return blk.Block()
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		require.NoError(t, validateAmounts(newDepositBlock(t, params.BeaconConfig().MaxEffectiveBalance)))
+		require.NoError(t, validateAmounts(newWithdrawalBlock(t, 1)))
+		require.NoError(t, validateAmounts(newDepositBlock(t, maxAmountGwei)))
+	})
+	t.Run("zero deposit", func(t *testing.T) {
+		assert.ErrorContains(t, "invalid amount of deposit 0: amount is zero", validateAmounts(newDepositBlock(t, 0)))
+	})
+	t.Run("deposit too large", func(t *testing.T) {
+		assert.ErrorContains(t, "invalid amount of deposit 0: amount 200000000000000001 exceeds the maximum", validateAmounts(newDepositBlock(t, maxAmountGwei+1)))
+		assert.ErrorContains(t, "exceeds the maximum", validateAmounts(newDepositBlock(t, math.MaxUint64)))
+	})
+	t.Run("zero withdrawal", func(t *testing.T) {
+		assert.ErrorContains(t, "invalid amount of withdrawal 0: amount is zero", validateAmounts(newWithdrawalBlock(t, 0)))
+	})
+	t.Run("withdrawal too large", func(t *testing.T) {
+		assert.ErrorContains(t, "invalid amount of withdrawal 0: amount 18446744073709551615 exceeds the maximum", validateAmounts(newWithdrawalBlock(t, math.MaxUint64)))
+	})
+}
+
+func TestPublishBlockV2_WaitForInclusion(t *testing.T) {
+	var b *SignedBeaconBlock
+	require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+	genericBlk, err := b.ToGeneric()
+	require.NoError(t, err)
+	blk, err := blocks.NewSignedBeaconBlock(genericBlk.Block)
+	require.NoError(t, err)
+	root, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	t.Run("included", func(t *testing.T) {
+		chainService := &testing2.ChainService{Root: root[:]}
+		server := &Server{
+			V1Alpha1ValidatorServer: &testutil.MockValidatorServer{},
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			HeadFetcher:             chainService,
+			ForkchoiceFetcher:       chainService,
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example?wait_for_inclusion=true", bytes.NewReader([]byte(phase0Block)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &PublishBlockResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, hexutil.Encode(root[:]), resp.Data.Root)
+		assert.Equal(t, hexutil.Encode(root[:]), resp.Data.Head)
+	})
+	t.Run("timeout", func(t *testing.T) {
+		chainService := &testing2.ChainService{Root: bytesutil.PadTo([]byte("head"), 32), ForkChoiceStore: doublylinkedtree.New()}
+		server := &Server{
+			V1Alpha1ValidatorServer: &testutil.MockValidatorServer{},
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			HeadFetcher:             chainService,
+			ForkchoiceFetcher:       chainService,
+			InclusionTimeout:        10 * time.Millisecond,
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example?wait_for_inclusion=true", bytes.NewReader([]byte(phase0Block)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusAccepted, writer.Code)
+		resp := &PublishBlockResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, hexutil.Encode(root[:]), resp.Data.Root)
+		assert.Equal(t, "", resp.Data.Head)
+	})
+}
+
+func TestPublishBlockV2_ConvertOnly(t *testing.T) {
+	activateForksAtGenesis(t)
+
+	v1alpha1Server := &testutil.MockValidatorServer{}
+	server := &Server{
+		V1Alpha1ValidatorServer: v1alpha1Server,
+		SyncChecker:             &mockSync.Sync{IsSyncing: false},
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "http://foo.example?convert_only=true", bytes.NewReader([]byte(capellaBlock)))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	server.PublishBlockV2(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, "application/octet-stream", writer.Header().Get("Content-Type"))
+	assert.Equal(t, version.String(version.Capella), writer.Header().Get(api.VersionHeader))
+	assert.Equal(t, 0, len(v1alpha1Server.ProposedBlocks))
+
+	var b *SignedBeaconBlockCapella
+	require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+	expected, err := b.ToGeneric()
+	require.NoError(t, err)
+	converted := &eth.SignedBeaconBlockCapella{}
+	require.NoError(t, converted.UnmarshalSSZ(writer.Body.Bytes()))
+	assert.DeepSSZEqual(t, expected.GetCapella(), converted)
+}
+
+func TestPublishBlockWithDetachedSignature(t *testing.T) {
+	activateForksAtGenesis(t)
+
+	var signed struct {
+		Message   json.RawMessage `json:"message"`
+		Signature string          `json:"signature"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(capellaBlock), &signed))
+	detachedBody := func(t *testing.T, block json.RawMessage, signature string) []byte {
+		body, err := json.Marshal(&DetachedSignatureBlock{Block: block, Signature: signature})
+		require.NoError(t, err)
+		return body
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		v1alpha1Server := &testutil.MockValidatorServer{}
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(detachedBody(t, signed.Message, signed.Signature)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockWithDetachedSignature(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		require.Equal(t, 1, len(v1alpha1Server.ProposedBlocks))
+
+		var b *SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+		expected, err := b.ToGeneric()
+		require.NoError(t, err)
+		assert.DeepSSZEqual(t, expected.GetCapella(), v1alpha1Server.ProposedBlocks[0].GetCapella())
+	})
+	t.Run("short signature", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(detachedBody(t, signed.Message, "0x01")))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockWithDetachedSignature(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "invalid signature: signature must be 96 bytes, got 1", writer.Body.String())
+	})
+	t.Run("missing block", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(detachedBody(t, nil, signed.Signature)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockWithDetachedSignature(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "block is required", writer.Body.String())
+	})
+	t.Run("incomplete block", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(detachedBody(t, json.RawMessage(`{"slot":"1"}`), signed.Signature)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockWithDetachedSignature(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Body does not represent a valid block type", writer.Body.String())
+	})
+}
+
+func TestSszBlockSlot(t *testing.T) {
+	var cblock SignedBeaconBlockCapella
+	require.NoError(t, json.Unmarshal([]byte(capellaBlock), &cblock))
+	sszvalue, err := cblock.MarshalSSZ()
+	require.NoError(t, err)
+
+	slot, ok := sszBlockSlot(sszvalue)
+	require.Equal(t, true, ok)
+	assert.Equal(t, primitives.Slot(1), slot)
+	_, ok = sszBlockSlot(sszvalue[:3])
+	assert.Equal(t, false, ok)
+	_, ok = sszBlockSlot(sszvalue[:100])
+	assert.Equal(t, false, ok)
+}
+
+func TestPublishBlockV2_ConversionErrorMetric(t *testing.T) {
+	const label = "b.Message.Body.Attestations[].Data.Slot"
+	var b *SignedBeaconBlock
+	require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+	b.Message.Body.Attestations[0].Data.Slot = "foo"
+	body, err := json.Marshal(b)
+	require.NoError(t, err)
+	server := &Server{SyncChecker: &mockSync.Sync{IsSyncing: false}}
+
+	t.Run("not recorded outside of publishing", func(t *testing.T) {
+		before := promtestutil.ToFloat64(blockConversionErrorCount.WithLabelValues(label))
+		_, err := b.ToGeneric()
+		require.NotNil(t, err)
+		_, err = b.MarshalSSZ()
+		require.NotNil(t, err)
+		assert.Equal(t, before, promtestutil.ToFloat64(blockConversionErrorCount.WithLabelValues(label)))
+	})
+	t.Run("recorded once", func(t *testing.T) {
+		before := promtestutil.ToFloat64(blockConversionErrorCount.WithLabelValues(label))
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.Equal(t, before+1, promtestutil.ToFloat64(blockConversionErrorCount.WithLabelValues(label)))
+	})
+	t.Run("recorded once with version header", func(t *testing.T) {
+		before := promtestutil.ToFloat64(blockConversionErrorCount.WithLabelValues(label))
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(body))
+		request.Header.Set(api.VersionHeader, version.String(version.Phase0))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.Equal(t, before+1, promtestutil.ToFloat64(blockConversionErrorCount.WithLabelValues(label)))
+	})
+}
+
+func TestPublishBlocks(t *testing.T) {
+	activateForksAtGenesis(t)
+
+	var invalid *SignedBeaconBlockCapella
+	require.NoError(t, json.Unmarshal([]byte(capellaBlock), &invalid))
+	invalid.Message.Slot = "foo"
+	invalidBlock, err := json.Marshal(invalid)
+	require.NoError(t, err)
+	batch := "[" + capellaBlock + "," + string(invalidBlock) + "," + capellaBlock + "]"
+
+	t.Run("mixed batch", func(t *testing.T) {
+		v1alpha1Server := &testutil.MockValidatorServer{}
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(batch)))
+		request.Header.Set(api.VersionHeader, "capella")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlocks(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, 2, len(v1alpha1Server.ProposedBlocks))
+
+		resp := &PublishBlocksResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 3, len(resp.Data))
+		assert.Equal(t, http.StatusOK, resp.Data[0].Code)
+		require.NotNil(t, resp.Data[0].Data)
+		assert.Equal(t, "1", resp.Data[0].Data.Slot)
+		assert.Equal(t, http.StatusBadRequest, resp.Data[1].Code)
+		assert.Equal(t, true, resp.Data[1].Data == nil)
+		assert.StringContains(t, "could not decode b.Message.Slot", resp.Data[1].Message)
+		assert.Equal(t, http.StatusOK, resp.Data[2].Code)
+	})
+	t.Run("stop on error", func(t *testing.T) {
+		v1alpha1Server := &testutil.MockValidatorServer{}
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example?stop_on_error=true", bytes.NewReader([]byte(batch)))
+		request.Header.Set(api.VersionHeader, "capella")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlocks(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, 1, len(v1alpha1Server.ProposedBlocks))
+
+		resp := &PublishBlocksResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, http.StatusOK, resp.Data[0].Code)
+		assert.Equal(t, http.StatusBadRequest, resp.Data[1].Code)
+	})
+	t.Run("missing version header", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(batch)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlocks(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, api.VersionHeader+" header is required", writer.Body.String())
+	})
+	t.Run("not an array", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(capellaBlock)))
+		request.Header.Set(api.VersionHeader, "capella")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlocks(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Could not decode request body", writer.Body.String())
+	})
+	t.Run("empty batch", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte("[]")))
+		request.Header.Set(api.VersionHeader, "capella")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlocks(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "No blocks provided", writer.Body.String())
+	})
+}
+
+func TestPublishBlockV2_ForkEpoch(t *testing.T) {
+	server := &Server{
+		SyncChecker: &mockSync.Sync{IsSyncing: false},
+	}
+
+	request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(capellaBlock)))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	server.PublishBlockV2(writer, request)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.StringContains(t, fmt.Sprintf("capella block at slot 1 is not allowed before the fork epoch %d", params.BeaconConfig().CapellaForkEpoch), writer.Body.String())
+}
+
+// activateForksAtGenesis activates every fork from genesis, so that the fixture blocks,
+// all of which are at early slots, are allowed to be published.
+func activateForksAtGenesis(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+}
+
+func TestPublishBlockV2_StrictAmountValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	var b *SignedBeaconBlock
+	require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+	b.Message.Body.Deposits[0].Data.Amount = "0"
+	body, err := json.Marshal(b)
+	require.NoError(t, err)
+
+	t.Run("disabled", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), gomock.Any())
+		server := &Server{
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+	})
+	t.Run("enabled", func(t *testing.T) {
+		server := &Server{
+			SyncChecker:            &mockSync.Sync{IsSyncing: false},
+			StrictAmountValidation: true,
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "amount validation failed: invalid amount of deposit 0: amount is zero", writer.Body.String())
+	})
+}
+
+func TestPublishBlockV2_LenientJSONDecoding(t *testing.T) {
+	activateForksAtGenesis(t)
+	ctrl := gomock.NewController(t)
+
+	body := strings.Replace(capellaBlock, "{", `{"extra_field": "1",`, 1)
+
+	t.Run("strict", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(body)))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Body does not represent a valid block type", writer.Body.String())
+	})
+	t.Run("strict with version header", func(t *testing.T) {
+		server := &Server{
+			SyncChecker: &mockSync.Sync{IsSyncing: false},
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(body)))
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "unknown field", writer.Body.String())
+	})
+	t.Run("lenient", func(t *testing.T) {
 		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
 		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
-			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_BlindedCapella)
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_Capella)
 			return ok
 		}))
 		server := &Server{
 			V1Alpha1ValidatorServer: v1alpha1Server,
 			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			LenientJSONDecoding:     true,
 		}
 
-		var cblock SignedBlindedBeaconBlockCapella
-		err := json.Unmarshal([]byte(blindedCapellaBlock), &cblock)
-		require.NoError(t, err)
-		genericBlock, err := cblock.ToGeneric()
-		require.NoError(t, err)
-		sszvalue, err := genericBlock.GetBlindedCapella().MarshalSSZ()
-		require.NoError(t, err)
-		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader(sszvalue))
-		request.Header.Set("Accept", "application/octet-stream")
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(body)))
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
-		server.PublishBlindedBlockV2(writer, request)
+		server.PublishBlockV2(writer, request)
 		assert.Equal(t, http.StatusOK, writer.Code)
 	})
-	t.Run("invalid block", func(t *testing.T) {
+	t.Run("lenient with version header", func(t *testing.T) {
+		v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+		v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), mock.MatchedBy(func(req *eth.GenericSignedBeaconBlock) bool {
+			_, ok := req.Block.(*eth.GenericSignedBeaconBlock_Capella)
+			return ok
+		}))
 		server := &Server{
-			SyncChecker: &mockSync.Sync{IsSyncing: false},
+			V1Alpha1ValidatorServer: v1alpha1Server,
+			SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			LenientJSONDecoding:     true,
 		}
 
-		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(bellatrixBlock)))
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", bytes.NewReader([]byte(body)))
+		request.Header.Set(api.VersionHeader, version.String(version.Capella))
 		writer := httptest.NewRecorder()
 		writer.Body = &bytes.Buffer{}
-		server.PublishBlindedBlockV2(writer, request)
-		assert.Equal(t, http.StatusBadRequest, writer.Code)
-		assert.Equal(t, true, strings.Contains(writer.Body.String(), "Body does not represent a valid block type"))
+		server.PublishBlockV2(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
 	})
 }
 
-func TestValidateConsensus(t *testing.T) {
-	ctx := context.Background()
+func TestValidateDepositCount(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, st.SetEth1Data(&eth.Eth1Data{DepositCount: 10, DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)}))
+	newBlock := func(t *testing.T, depositCount uint64) interfaces.ReadOnlyBeaconBlock {
+		b := util.NewBeaconBlock()
+		b.Block.Body.Eth1Data.DepositCount = depositCount
+		blk, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		return blk.Block()
+	}
 
-	parentState, privs := util.DeterministicGenesisState(t, params.MinimalSpecConfig().MinGenesisActiveValidatorCount)
-	parentBlock, err := util.GenerateFullBlock(parentState, privs, util.DefaultBlockGenConfig(), parentState.Slot())
-	require.NoError(t, err)
-	parentSbb, err := blocks.NewSignedBeaconBlock(parentBlock)
-	require.NoError(t, err)
-	st, err := transition.ExecuteStateTransition(ctx, parentState, parentSbb)
-	require.NoError(t, err)
-	block, err := util.GenerateFullBlock(st, privs, util.DefaultBlockGenConfig(), st.Slot())
-	require.NoError(t, err)
-	sbb, err := blocks.NewSignedBeaconBlock(block)
-	require.NoError(t, err)
-	parentRoot, err := parentSbb.Block().HashTreeRoot()
-	require.NoError(t, err)
-	server := &Server{
-		Blocker: &testutil.MockBlocker{RootBlockMap: map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{parentRoot: parentSbb}},
-		Stater:  &testutil.MockStater{StatesByRoot: map[[32]byte]state.BeaconState{bytesutil.ToBytes32(parentBlock.Block.StateRoot): parentState}},
+	t.Run("same count", func(t *testing.T) {
+		require.NoError(t, validateDepositCount(st, newBlock(t, 10)))
+	})
+	t.Run("higher count", func(t *testing.T) {
+		require.NoError(t, validateDepositCount(st, newBlock(t, 11)))
+	})
+	t.Run("regressing count", func(t *testing.T) {
+		err := validateDepositCount(st, newBlock(t, 9))
+		assert.ErrorContains(t, "eth1 data deposit count 9 is lower than the parent state's deposit count 10", err)
+	})
+}
+
+func TestValidateCommitteeIndices(t *testing.T) {
+	st, _ := util.DeterministicGenesisState(t, 64)
+	newBlock := func(t *testing.T, committeeIndex primitives.CommitteeIndex) interfaces.ReadOnlyBeaconBlock {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = 2
+		b.Block.Body.Attestations = []*eth.Attestation{util.HydrateAttestation(&eth.Attestation{
+			Data: &eth.AttestationData{Slot: 1, CommitteeIndex: committeeIndex},
+		})}
+		blk, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		return blk.Block()
 	}
 
-	require.NoError(t, server.validateConsensus(ctx, sbb))
+	t.Run("ok", func(t *testing.T) {
+		require.NoError(t, validateCommitteeIndices(context.Background(), st, newBlock(t, 0)))
+	})
+	t.Run("committee index out of range", func(t *testing.T) {
+		err := validateCommitteeIndices(context.Background(), st, newBlock(t, 1))
+		assert.ErrorContains(t, "attestation 0 has committee index 1 but slot 1 only has 1 committees", err)
+	})
 }
 
 func TestValidateEquivocation(t *testing.T) {
@@ -414,6 +2328,29 @@ func TestValidateEquivocation(t *testing.T) {
 		require.NoError(t, err)
 		blk.SetSlot(st.Slot())
 
+		assert.ErrorContains(t, "already exists", server.validateEquivocation(blk.Block()))
+	})
+	t.Run("empty fork choice", func(t *testing.T) {
+		server := &Server{
+			ForkchoiceFetcher: &testing2.ChainService{ForkChoiceStore: doublylinkedtree.New()},
+		}
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+		require.NoError(t, err)
+		require.Equal(t, primitives.Slot(0), blk.Block().Slot())
+
+		require.NoError(t, server.validateEquivocation(blk.Block()))
+	})
+	t.Run("block already exists at slot 0", func(t *testing.T) {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		fc := doublylinkedtree.New()
+		require.NoError(t, fc.InsertNode(context.Background(), st, bytesutil.ToBytes32([]byte("root"))))
+		server := &Server{
+			ForkchoiceFetcher: &testing2.ChainService{ForkChoiceStore: fc},
+		}
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+		require.NoError(t, err)
+
 		assert.ErrorContains(t, "already exists", server.validateEquivocation(blk.Block()))
 	})
 }
@@ -552,6 +2489,7 @@ const (
             "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
             "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
             "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
+            "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
             "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"
           ],
           "data": {
@@ -708,6 +2646,7 @@ const (
             "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
             "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
             "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
+            "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2",
             "0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"
           ],
           "data": {
@@ -728,7 +2667,7 @@ const (
         }
       ],
       "sync_aggregate": {
-        "sync_committee_bits": "0x01",
+        "sync_committee_bits": "0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
         "sync_committee_signature": "0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505"
       }
     }
@@ -1477,3 +3416,735 @@ const (
   "signature": "0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505"
 }`
 )
+
+func TestGetFork(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 10
+	cfg.BellatrixForkEpoch = 20
+	cfg.CapellaForkEpoch = 30
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+	server := &Server{}
+
+	tests := []struct {
+		name            string
+		query           string
+		previousVersion []byte
+		currentVersion  []byte
+		epoch           string
+	}{
+		{name: "genesis", query: "epoch=0", previousVersion: cfg.GenesisForkVersion, currentVersion: cfg.GenesisForkVersion, epoch: "0"},
+		{name: "last phase 0 epoch", query: "epoch=9", previousVersion: cfg.GenesisForkVersion, currentVersion: cfg.GenesisForkVersion, epoch: "0"},
+		{name: "altair fork epoch", query: "epoch=10", previousVersion: cfg.GenesisForkVersion, currentVersion: cfg.AltairForkVersion, epoch: "10"},
+		{name: "last altair epoch", query: "epoch=19", previousVersion: cfg.GenesisForkVersion, currentVersion: cfg.AltairForkVersion, epoch: "10"},
+		{name: "bellatrix fork epoch", query: "epoch=20", previousVersion: cfg.AltairForkVersion, currentVersion: cfg.BellatrixForkVersion, epoch: "20"},
+		{name: "last bellatrix slot", query: fmt.Sprintf("slot=%d", 30*cfg.SlotsPerEpoch-1), previousVersion: cfg.AltairForkVersion, currentVersion: cfg.BellatrixForkVersion, epoch: "20"},
+		{name: "first capella slot", query: fmt.Sprintf("slot=%d", 30*cfg.SlotsPerEpoch), previousVersion: cfg.BellatrixForkVersion, currentVersion: cfg.CapellaForkVersion, epoch: "30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/beacon/fork?"+tt.query, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			server.GetFork(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			resp := &GetForkResponse{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			assert.Equal(t, hexutil.Encode(tt.previousVersion), resp.Data.PreviousVersion)
+			assert.Equal(t, hexutil.Encode(tt.currentVersion), resp.Data.CurrentVersion)
+			assert.Equal(t, tt.epoch, resp.Data.Epoch)
+		})
+	}
+	t.Run("no epoch or slot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/beacon/fork", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetFork(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Either epoch or slot is required", writer.Body.String())
+	})
+	t.Run("both epoch and slot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/beacon/fork?epoch=1&slot=32", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetFork(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Only one of epoch and slot can be provided", writer.Body.String())
+	})
+	t.Run("invalid epoch", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/beacon/fork?epoch=foo", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetFork(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "epoch is invalid", writer.Body.String())
+	})
+}
+
+func TestScheduledForkVersion(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 10
+	cfg.BellatrixForkEpoch = 20
+	cfg.CapellaForkEpoch = 30
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+	server := &Server{}
+
+	// Publishing validates the Eth-Consensus-Version header against the standard fork schedule.
+	schedule, err := server.GetForkSchedule(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, len(blockForkSchedule()), len(schedule.Data))
+	for _, f := range schedule.Data {
+		v, err := version.FromString(cfg.ForkVersionNames[bytesutil.ToBytes4(f.CurrentVersion)])
+		require.NoError(t, err)
+		assert.Equal(t, v, scheduledForkVersion(f.Epoch))
+		if f.Epoch > 0 {
+			assert.NotEqual(t, v, scheduledForkVersion(f.Epoch-1))
+		}
+	}
+}
+
+func TestGetBlockExecutionPayload(t *testing.T) {
+	bellatrix := util.NewBeaconBlockBellatrix()
+	bellatrix.Block.Slot = 1
+	bellatrix.Block.Body.ExecutionPayload.BlockNumber = 1
+	bellatrix.Block.Body.ExecutionPayload.Transactions = [][]byte{{'a'}}
+	blindedBellatrix := util.NewBlindedBeaconBlockBellatrix()
+	blindedBellatrix.Block.Slot = 2
+	blindedBellatrix.Block.Body.ExecutionPayloadHeader.BlockNumber = 2
+	capella := util.NewBeaconBlockCapella()
+	capella.Block.Slot = 3
+	capella.Block.Body.ExecutionPayload.BlockNumber = 3
+	capella.Block.Body.ExecutionPayload.Withdrawals = []*enginev1.Withdrawal{{Index: 1, Address: make([]byte, 20), Amount: 2}}
+	blindedCapella := util.NewBlindedBeaconBlockCapella()
+	blindedCapella.Block.Slot = 4
+	blindedCapella.Block.Body.ExecutionPayloadHeader.BlockNumber = 4
+
+	blockMap := make(map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock)
+	for _, b := range []interface{}{util.NewBeaconBlock(), bellatrix, blindedBellatrix, capella, blindedCapella} {
+		blk, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		blockMap[blk.Block().Slot()] = blk
+	}
+	mockChainService := &testing2.ChainService{}
+	server := &Server{
+		Blocker:               &testutil.MockBlocker{SlotBlockMap: blockMap},
+		OptimisticModeFetcher: mockChainService,
+		FinalizationFetcher:   mockChainService,
+		GenesisTimeFetcher:    mockChainService,
+	}
+	getPayload := func(t *testing.T, blockID string, ssz bool) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/prysm/beacon/blocks/"+blockID+"/execution_payload", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": blockID})
+		if ssz {
+			request.Header.Set("Accept", api.OctetStreamMediaType)
+		}
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockExecutionPayload(writer, request)
+		return writer
+	}
+
+	t.Run("Bellatrix", func(t *testing.T) {
+		writer := getPayload(t, "1", false)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, version.String(version.Bellatrix), writer.Header().Get(api.VersionHeader))
+		resp := &struct {
+			GetBlockExecutionPayloadResponse
+			Data *ExecutionPayload `json:"data"`
+		}{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, version.String(version.Bellatrix), resp.Version)
+		assert.Equal(t, false, resp.Blinded)
+		assert.Equal(t, "1", resp.Data.BlockNumber)
+		assert.DeepEqual(t, []string{"0x61"}, resp.Data.Transactions)
+	})
+	t.Run("blinded Bellatrix", func(t *testing.T) {
+		writer := getPayload(t, "2", false)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &struct {
+			GetBlockExecutionPayloadResponse
+			Data *ExecutionPayloadHeader `json:"data"`
+		}{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, version.String(version.Bellatrix), resp.Version)
+		assert.Equal(t, true, resp.Blinded)
+		assert.Equal(t, "2", resp.Data.BlockNumber)
+		assert.Equal(t, hexutil.Encode(blindedBellatrix.Block.Body.ExecutionPayloadHeader.TransactionsRoot), resp.Data.TransactionsRoot)
+	})
+	t.Run("Capella", func(t *testing.T) {
+		writer := getPayload(t, "3", false)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &struct {
+			GetBlockExecutionPayloadResponse
+			Data *ExecutionPayloadCapella `json:"data"`
+		}{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, version.String(version.Capella), resp.Version)
+		assert.Equal(t, false, resp.Blinded)
+		assert.Equal(t, "3", resp.Data.BlockNumber)
+		require.Equal(t, 1, len(resp.Data.Withdrawals))
+		assert.Equal(t, "2", resp.Data.Withdrawals[0].Amount)
+	})
+	t.Run("blinded Capella", func(t *testing.T) {
+		writer := getPayload(t, "4", false)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &struct {
+			GetBlockExecutionPayloadResponse
+			Data *ExecutionPayloadHeaderCapella `json:"data"`
+		}{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, version.String(version.Capella), resp.Version)
+		assert.Equal(t, true, resp.Blinded)
+		assert.Equal(t, "4", resp.Data.BlockNumber)
+		assert.Equal(t, hexutil.Encode(blindedCapella.Block.Body.ExecutionPayloadHeader.WithdrawalsRoot), resp.Data.WithdrawalsRoot)
+	})
+	t.Run("SSZ", func(t *testing.T) {
+		for blockID, payload := range map[string]interface{ MarshalSSZ() ([]byte, error) }{
+			"1": bellatrix.Block.Body.ExecutionPayload,
+			"2": blindedBellatrix.Block.Body.ExecutionPayloadHeader,
+			"3": capella.Block.Body.ExecutionPayload,
+			"4": blindedCapella.Block.Body.ExecutionPayloadHeader,
+		} {
+			writer := getPayload(t, blockID, true)
+			require.Equal(t, http.StatusOK, writer.Code)
+			expected, err := payload.MarshalSSZ()
+			require.NoError(t, err)
+			assert.DeepEqual(t, expected, writer.Body.Bytes())
+		}
+	})
+	t.Run("Phase 0", func(t *testing.T) {
+		writer := getPayload(t, "0", false)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "Blocks of the phase0 fork have no execution payload", writer.Body.String())
+	})
+}
+
+func TestGetBlockSlashings(t *testing.T) {
+	attSlashing := &eth.AttesterSlashing{
+		Attestation_1: util.HydrateIndexedAttestation(&eth.IndexedAttestation{AttestingIndices: []uint64{1, 2}}),
+		Attestation_2: util.HydrateIndexedAttestation(&eth.IndexedAttestation{AttestingIndices: []uint64{2, 3}}),
+	}
+	proposerSlashing := &eth.ProposerSlashing{
+		Header_1: util.HydrateSignedBeaconHeader(&eth.SignedBeaconBlockHeader{Header: &eth.BeaconBlockHeader{ProposerIndex: 4}}),
+		Header_2: util.HydrateSignedBeaconHeader(&eth.SignedBeaconBlockHeader{Header: &eth.BeaconBlockHeader{ProposerIndex: 4, Slot: 1}}),
+	}
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 123
+	b.Block.Body.AttesterSlashings = []*eth.AttesterSlashing{attSlashing}
+	b.Block.Body.ProposerSlashings = []*eth.ProposerSlashing{proposerSlashing}
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	root, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+	emptyBlk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+
+	mockChainService := &testing2.ChainService{
+		FinalizedRoots:  map[[32]byte]bool{root: true},
+		OptimisticRoots: map[[32]byte]bool{root: true},
+		Genesis:         time.Unix(1606824023, 0),
+	}
+	server := &Server{
+		Blocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
+			123: blk,
+			0:   emptyBlk,
+		}},
+		OptimisticModeFetcher: mockChainService,
+		FinalizationFetcher:   mockChainService,
+		GenesisTimeFetcher:    mockChainService,
+	}
+
+	t.Run("attester slashings", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/123/attester_slashings", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "123"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockAttesterSlashings(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetBlockAttesterSlashingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		assert.Equal(t, true, resp.Finalized)
+		require.Equal(t, 1, len(resp.Data))
+		assert.DeepEqual(t, []string{"1", "2"}, resp.Data[0].Attestation1.AttestingIndices)
+		assert.DeepEqual(t, []string{"2", "3"}, resp.Data[0].Attestation2.AttestingIndices)
+		converted, err := convertAttesterSlashings(resp.Data)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []*eth.AttesterSlashing{attSlashing}, converted)
+	})
+	t.Run("proposer slashings", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/123/proposer_slashings", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "123"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockProposerSlashings(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetBlockProposerSlashingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		assert.Equal(t, true, resp.Finalized)
+		require.Equal(t, 1, len(resp.Data))
+		converted, err := convertProposerSlashings(resp.Data)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []*eth.ProposerSlashing{proposerSlashing}, converted)
+	})
+	t.Run("not modified", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/123/attester_slashings", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "123"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockAttesterSlashings(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		etag := writer.Header().Get("ETag")
+		require.NotEqual(t, "", etag)
+
+		request = httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/123/attester_slashings", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "123"})
+		request.Header.Set("If-None-Match", etag)
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockAttesterSlashings(writer, request)
+		assert.Equal(t, http.StatusNotModified, writer.Code)
+		assert.Equal(t, 0, writer.Body.Len())
+	})
+	t.Run("not modified since", func(t *testing.T) {
+		headBlk := util.NewBeaconBlock()
+		headBlk.Block.Slot = 123
+		headBlk.Block.ProposerIndex = 1
+		reorgedBlk := util.NewBeaconBlock()
+		reorgedBlk.Block.Slot = 123
+		reorgedBlk.Block.ProposerIndex = 2
+		head, err := blocks.NewSignedBeaconBlock(headBlk)
+		require.NoError(t, err)
+		headRoot, err := head.Block().HashTreeRoot()
+		require.NoError(t, err)
+		reorged, err := blocks.NewSignedBeaconBlock(reorgedBlk)
+		require.NoError(t, err)
+		chainService := &testing2.ChainService{
+			FinalizedRoots:  map[[32]byte]bool{},
+			OptimisticRoots: map[[32]byte]bool{},
+			Genesis:         mockChainService.Genesis,
+		}
+		blocker := &testutil.MockBlocker{BlockToReturn: head}
+		server := &Server{
+			Blocker:               blocker,
+			OptimisticModeFetcher: chainService,
+			FinalizationFetcher:   chainService,
+			GenesisTimeFetcher:    chainService,
+		}
+		slotStart := chainService.Genesis.Add(time.Duration(123*params.BeaconConfig().SecondsPerSlot) * time.Second)
+		// Any time after the start of the slot, which a non-finalized block would be dated with.
+		afterSlotStart := slotStart.Add(time.Second).UTC().Format(http.TimeFormat)
+		getSlashings := func(ifModifiedSince string) (*httptest.ResponseRecorder, *GetBlockAttesterSlashingsResponse) {
+			request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/head/attester_slashings", nil)
+			request = mux.SetURLVars(request, map[string]string{"block_id": "head"})
+			if ifModifiedSince != "" {
+				request.Header.Set("If-Modified-Since", ifModifiedSince)
+			}
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			server.GetBlockAttesterSlashings(writer, request)
+			resp := &GetBlockAttesterSlashingsResponse{}
+			if writer.Code == http.StatusOK {
+				require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			}
+			return writer, resp
+		}
+
+		// A block that isn't finalized is not dated.
+		writer, resp := getSlashings(afterSlotStart)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "", writer.Header().Get("Last-Modified"))
+		assert.Equal(t, false, resp.Finalized)
+
+		// The head changes to another block of the same slot.
+		blocker.BlockToReturn = reorged
+		writer, _ = getSlashings(afterSlotStart)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "", writer.Header().Get("Last-Modified"))
+		reorgedRoot, err := reorged.Block().HashTreeRoot()
+		require.NoError(t, err)
+		assert.StringContains(t, fmt.Sprintf("%#x", reorgedRoot), writer.Header().Get("ETag"))
+
+		// The head changes back, and the block becomes finalized while still being optimistic.
+		blocker.BlockToReturn = head
+		chainService.FinalizedRoots[headRoot] = true
+		chainService.OptimisticRoots[headRoot] = true
+		writer, resp = getSlashings(afterSlotStart)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "", writer.Header().Get("Last-Modified"))
+		assert.Equal(t, true, resp.Finalized)
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+
+		// The block is no longer optimistic, so its response never changes again.
+		chainService.OptimisticRoots[headRoot] = false
+		writer, resp = getSlashings("")
+		assert.Equal(t, http.StatusOK, writer.Code)
+		lastModified := writer.Header().Get("Last-Modified")
+		assert.Equal(t, slotStart.UTC().Format(http.TimeFormat), lastModified)
+		assert.Equal(t, true, resp.Finalized)
+		assert.Equal(t, false, resp.ExecutionOptimistic)
+		writer, _ = getSlashings(lastModified)
+		assert.Equal(t, http.StatusNotModified, writer.Code)
+		assert.Equal(t, 0, writer.Body.Len())
+	})
+	t.Run("cache control", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/123/attester_slashings", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "123"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockAttesterSlashings(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, fmt.Sprintf("public, max-age=%d", params.BeaconConfig().SecondsPerSlot), writer.Header().Get("Cache-Control"))
+
+		request = httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/0/attester_slashings", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "0"})
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockAttesterSlashings(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "no-cache", writer.Header().Get("Cache-Control"))
+	})
+	t.Run("paginated", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/123/attester_slashings?offset=1&limit=1", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "123"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockAttesterSlashings(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetBlockAttesterSlashingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, 0, len(resp.Data))
+		assert.Equal(t, "1", resp.Total)
+
+		request = httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/123/proposer_slashings?limit=1", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "123"})
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockProposerSlashings(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		proposerResp := &GetBlockProposerSlashingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), proposerResp))
+		assert.Equal(t, 1, len(proposerResp.Data))
+		assert.Equal(t, "1", proposerResp.Total)
+	})
+	t.Run("invalid limit", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/123/attester_slashings?limit=0", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "123"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockAttesterSlashings(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("no slashings", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/0/attester_slashings", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "0"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockAttesterSlashings(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.StringContains(t, `"data":[]`, writer.Body.String())
+		assert.StringContains(t, `"finalized":false`, writer.Body.String())
+	})
+	t.Run("block not found", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks/1/proposer_slashings", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "1"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockProposerSlashings(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("wrong method", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example/eth/v1/beacon/blocks/123/attester_slashings", nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": "123"})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockAttesterSlashings(writer, request)
+		assert.Equal(t, http.StatusMethodNotAllowed, writer.Code)
+		assert.Equal(t, http.MethodGet, writer.Header().Get("Allow"))
+
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockProposerSlashings(writer, request)
+		assert.Equal(t, http.StatusMethodNotAllowed, writer.Code)
+		assert.Equal(t, http.MethodGet, writer.Header().Get("Allow"))
+	})
+	t.Run("no block ID", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v1/beacon/blocks//proposer_slashings", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockProposerSlashings(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "block_id is required", writer.Body.String())
+	})
+}
+
+func TestResolveBlockID(t *testing.T) {
+	ctx := context.Background()
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 123
+	blk, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	root, err := blk.Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	rootBlockMap := map[[32]byte]interfaces.ReadOnlySignedBeaconBlock{root: blk}
+	for _, keyword := range []string{"head", "genesis", "finalized", "justified"} {
+		// The mock blocker treats every non-numeric ID as a block root.
+		rootBlockMap[bytesutil.ToBytes32([]byte(keyword))] = blk
+	}
+	server := &Server{
+		Blocker: &testutil.MockBlocker{
+			SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{123: blk},
+			RootBlockMap: rootBlockMap,
+		},
+	}
+
+	for _, id := range []string{"head", "genesis", "finalized", "justified", "123", hexutil.Encode(root[:])} {
+		t.Run(id, func(t *testing.T) {
+			resolved, resolvedRoot, err := server.resolveBlockID(ctx, id)
+			require.NoError(t, err)
+			assert.Equal(t, blk, resolved)
+			assert.Equal(t, root, resolvedRoot)
+		})
+	}
+	t.Run("invalid format", func(t *testing.T) {
+		for _, id := range []string{"foo", "-1", "0xzz", "0x0102"} {
+			_, _, err := server.resolveBlockID(ctx, id)
+			var parseErr *lookup.BlockIdParseError
+			assert.Equal(t, true, errors.As(err, &parseErr), "expected parse error for block ID %s", id)
+			assert.Equal(t, http.StatusBadRequest, handleGetBlockHTTPError(err).Code)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		for _, id := range []string{"1", hexutil.Encode(make([]byte, 32))} {
+			_, _, err := server.resolveBlockID(ctx, id)
+			var notFoundErr *lookup.BlockNotFoundError
+			assert.Equal(t, true, errors.As(err, &notFoundErr), "expected not found error for block ID %s", id)
+			assert.Equal(t, http.StatusNotFound, handleGetBlockHTTPError(err).Code)
+		}
+	})
+	t.Run("blocker error", func(t *testing.T) {
+		server := &Server{Blocker: &testutil.MockBlocker{ErrorToReturn: errors.New("foo")}}
+		_, _, err := server.resolveBlockID(ctx, "head")
+		assert.ErrorContains(t, "foo", err)
+		assert.Equal(t, http.StatusInternalServerError, handleGetBlockHTTPError(err).Code)
+	})
+}
+
+func TestGetBlockV2HTTP(t *testing.T) {
+	resetFn := features.InitWithReset(&features.Flags{SaveFullExecutionPayloads: true})
+	defer resetFn()
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+
+	phase0Blk := util.NewBeaconBlock()
+	phase0Blk.Block.Slot = 1
+	altairBlk := util.NewBeaconBlockAltair()
+	altairBlk.Block.Slot = 2
+	bellatrixBlk := util.NewBeaconBlockBellatrix()
+	bellatrixBlk.Block.Slot = 3
+	capellaBlk := util.NewBeaconBlockCapella()
+	capellaBlk.Block.Slot = 4
+
+	tests := []struct {
+		name    string
+		blk     interface{}
+		version int
+	}{
+		{name: "phase0", blk: phase0Blk, version: version.Phase0},
+		{name: "altair", blk: altairBlk, version: version.Altair},
+		{name: "bellatrix", blk: bellatrixBlk, version: version.Bellatrix},
+		{name: "capella", blk: capellaBlk, version: version.Capella},
+	}
+	mockChainService := &testing2.ChainService{}
+	server := &Server{
+		Blocker:               &lookup.BeaconDbBlocker{BeaconDB: beaconDB, ChainInfoFetcher: mockChainService},
+		OptimisticModeFetcher: mockChainService,
+		FinalizationFetcher:   mockChainService,
+		GenesisTimeFetcher:    mockChainService,
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blk, err := blocks.NewSignedBeaconBlock(tt.blk)
+			require.NoError(t, err)
+			require.NoError(t, beaconDB.SaveBlock(ctx, blk))
+			root, err := blk.Block().HashTreeRoot()
+			require.NoError(t, err)
+			blockId := hexutil.Encode(root[:])
+
+			request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v2/beacon/blocks/"+blockId, nil)
+			request = mux.SetURLVars(request, map[string]string{"block_id": blockId})
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			server.GetBlockV2HTTP(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, version.String(tt.version), writer.Header().Get(api.VersionHeader))
+			resp := &GetBlockV2Response{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			assert.Equal(t, version.String(tt.version), resp.Version)
+			genericBlk, err := blk.PbGenericBlock()
+			require.NoError(t, err)
+			expected, _, err := FromGeneric(genericBlk)
+			require.NoError(t, err)
+			expectedJson, err := json.Marshal(expected)
+			require.NoError(t, err)
+			var expectedData interface{}
+			require.NoError(t, json.Unmarshal(expectedJson, &expectedData))
+			assert.DeepEqual(t, expectedData, resp.Data)
+
+			request = httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v2/beacon/blocks/"+blockId, nil)
+			request = mux.SetURLVars(request, map[string]string{"block_id": blockId})
+			request.Header.Set("Accept", "application/octet-stream")
+			writer = httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			server.GetBlockV2HTTP(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, version.String(tt.version), writer.Header().Get(api.VersionHeader))
+			expectedSsz, err := blk.MarshalSSZ()
+			require.NoError(t, err)
+			assert.DeepEqual(t, expectedSsz, writer.Body.Bytes())
+
+			request = httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v2/beacon/blocks/"+blockId+"?encoding=ssz_snappy", nil)
+			request = mux.SetURLVars(request, map[string]string{"block_id": blockId})
+			writer = httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			server.GetBlockV2HTTP(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			assert.Equal(t, "application/octet-stream+snappy", writer.Header().Get("Content-Type"))
+			decodedSsz, err := http2.DecodeSszSnappy(writer.Body.Bytes(), int64(len(expectedSsz)))
+			require.NoError(t, err)
+			assert.DeepEqual(t, expectedSsz, decodedSsz)
+		})
+	}
+	t.Run("not found", func(t *testing.T) {
+		blockId := hexutil.Encode(make([]byte, 32))
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v2/beacon/blocks/"+blockId, nil)
+		request = mux.SetURLVars(request, map[string]string{"block_id": blockId})
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		server.GetBlockV2HTTP(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+}
+
+func TestWriteBlockSSZ_EncodingError(t *testing.T) {
+	pb := util.NewBeaconBlockCapella()
+	pb.Block.Body.ExecutionPayload.ExtraData = make([]byte, 33)
+	blk, err := blocks.NewSignedBeaconBlock(pb)
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v2/beacon/blocks/head", nil)
+	request.Header.Set("Accept", "application/octet-stream")
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	writeBlockSSZ(writer, request, blk)
+	assert.Equal(t, http.StatusInternalServerError, writer.Code)
+	assert.Equal(t, "application/json", writer.Header().Get("Content-Type"))
+	e := &http2.DefaultErrorJson{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+	assert.Equal(t, http.StatusInternalServerError, e.Code)
+	assert.StringContains(t, "Could not marshal block into SSZ", e.Message)
+	assert.StringContains(t, "--.ExtraData", e.Message)
+}
+
+// TestGetBlockV2HTTP_Republish checks that the JSON representation of a block served by the node
+// can be published back to it unchanged, which guarantees that both conversions agree on every field.
+func TestGetBlockV2HTTP_Republish(t *testing.T) {
+	activateForksAtGenesis(t)
+	resetFn := features.InitWithReset(&features.Flags{SaveFullExecutionPayloads: true})
+	defer resetFn()
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	ctrl := gomock.NewController(t)
+
+	sig := bytesutil.PadTo([]byte{0x01}, 96)
+	atts := []*eth.Attestation{util.HydrateAttestation(&eth.Attestation{AggregationBits: bitfield.Bitlist{0b1101}})}
+	baseFee := bytesutil.PadTo([]byte{0x07, 0x01}, 32)
+
+	phase0Blk := util.NewBeaconBlock()
+	phase0Blk.Block.Slot = 1
+	phase0Blk.Block.Body.Attestations = atts
+	phase0Blk.Signature = sig
+	altairBlk := util.NewBeaconBlockAltair()
+	altairBlk.Block.Slot = 2
+	altairBlk.Block.Body.Attestations = atts
+	altairBlk.Signature = sig
+	bellatrixBlk := util.NewBeaconBlockBellatrix()
+	bellatrixBlk.Block.Slot = 3
+	bellatrixBlk.Block.Body.Attestations = atts
+	bellatrixBlk.Block.Body.ExecutionPayload.BaseFeePerGas = baseFee
+	bellatrixBlk.Block.Body.ExecutionPayload.Transactions = [][]byte{{0x01, 0x02}}
+	bellatrixBlk.Signature = sig
+	capellaBlk := util.NewBeaconBlockCapella()
+	capellaBlk.Block.Slot = 4
+	capellaBlk.Block.Body.Attestations = atts
+	capellaBlk.Block.Body.ExecutionPayload.BaseFeePerGas = baseFee
+	capellaBlk.Block.Body.ExecutionPayload.Transactions = [][]byte{{0x01, 0x02}}
+	capellaBlk.Block.Body.ExecutionPayload.Withdrawals = []*enginev1.Withdrawal{{Index: 1, ValidatorIndex: 2, Address: make([]byte, 20), Amount: 3}}
+	capellaBlk.Signature = sig
+
+	tests := []struct {
+		name    string
+		blk     interface{}
+		version int
+	}{
+		{name: "phase0", blk: phase0Blk, version: version.Phase0},
+		{name: "altair", blk: altairBlk, version: version.Altair},
+		{name: "bellatrix", blk: bellatrixBlk, version: version.Bellatrix},
+		{name: "capella", blk: capellaBlk, version: version.Capella},
+	}
+	mockChainService := &testing2.ChainService{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blk, err := blocks.NewSignedBeaconBlock(tt.blk)
+			require.NoError(t, err)
+			require.NoError(t, beaconDB.SaveBlock(ctx, blk))
+			root, err := blk.Block().HashTreeRoot()
+			require.NoError(t, err)
+			blockId := hexutil.Encode(root[:])
+
+			var proposed *eth.GenericSignedBeaconBlock
+			v1alpha1Server := mock2.NewMockBeaconNodeValidatorServer(ctrl)
+			v1alpha1Server.EXPECT().ProposeBeaconBlock(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, req *eth.GenericSignedBeaconBlock) (*eth.ProposeResponse, error) {
+					proposed = req
+					return &eth.ProposeResponse{}, nil
+				})
+			server := &Server{
+				Blocker:                 &lookup.BeaconDbBlocker{BeaconDB: beaconDB, ChainInfoFetcher: mockChainService},
+				OptimisticModeFetcher:   mockChainService,
+				FinalizationFetcher:     mockChainService,
+				GenesisTimeFetcher:      mockChainService,
+				V1Alpha1ValidatorServer: v1alpha1Server,
+				SyncChecker:             &mockSync.Sync{IsSyncing: false},
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "http://foo.example/eth/v2/beacon/blocks/"+blockId, nil)
+			request = mux.SetURLVars(request, map[string]string{"block_id": blockId})
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			server.GetBlockV2HTTP(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code)
+			resp := &GetBlockV2Response{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			body, err := json.Marshal(resp.Data)
+			require.NoError(t, err)
+
+			request = httptest.NewRequest(http.MethodPost, "http://foo.example/eth/v2/beacon/blocks", bytes.NewReader(body))
+			request.Header.Set(api.VersionHeader, resp.Version)
+			writer = httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			server.PublishBlockV2(writer, request)
+			require.Equal(t, http.StatusOK, writer.Code, writer.Body.String())
+			expected, err := blk.PbGenericBlock()
+			require.NoError(t, err)
+			assert.DeepSSZEqual(t, expected, proposed)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package beacon
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// blockConversionErrorCount tracks the blocks that could not be converted from their JSON representation,
+	// by the field that failed to decode. List indices are dropped from the field to keep the label bounded.
+	blockConversionErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_block_conversion_errors_total",
+		Help: "The number of blocks that could not be converted from JSON, by the field that failed to decode",
+	}, []string{"field"})
+)
+
+// recordConversionError counts a failed block conversion under the field the error belongs to.
+// Nothing is recorded for errors that don't come from converting a field, such as malformed JSON.
+func recordConversionError(err error) {
+	var fieldErr *fieldError
+	if !errors.As(err, &fieldErr) {
+		return
+	}
+	blockConversionErrorCount.WithLabelValues(conversionErrorField(fieldErr.field)).Inc()
+}
+
+// conversionErrorField turns the path of a field, such as b.Message.Body.Attestations[0].Data.Slot,
+// into its label by dropping list indices, giving b.Message.Body.Attestations[].Data.Slot.
+func conversionErrorField(field string) string {
+	var b strings.Builder
+	inIndex := false
+	for _, c := range field {
+		switch {
+		case c == '[':
+			inIndex = true
+			b.WriteRune(c)
+		case c == ']':
+			inIndex = false
+			b.WriteRune(c)
+		case !inIndex:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
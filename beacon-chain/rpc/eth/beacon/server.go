@@ -4,6 +4,10 @@
 package beacon
 
 import (
+	"context"
+	"sync/atomic"
+	"time"
+
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	blockfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/block"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
@@ -18,8 +22,17 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// V1Alpha1ValidatorServer is the subset of eth.BeaconNodeValidatorServer that the beacon
+// endpoints delegate to. Keeping it small allows handlers to be tested with a simple fake.
+type V1Alpha1ValidatorServer interface {
+	GetBeaconBlock(ctx context.Context, req *eth.BlockRequest) (*eth.GenericBeaconBlock, error)
+	ProposeBeaconBlock(ctx context.Context, req *eth.GenericSignedBeaconBlock) (*eth.ProposeResponse, error)
+	SubmitSyncMessage(ctx context.Context, msg *eth.SyncCommitteeMessage) (*emptypb.Empty, error)
+}
+
 // Server defines a server implementation of the gRPC Beacon Chain service,
 // providing RPC endpoints to access data relevant to the Ethereum Beacon Chain.
 type Server struct {
@@ -39,11 +52,31 @@ type Server struct {
 	HeadFetcher                   blockchain.HeadFetcher
 	TimeFetcher                   blockchain.TimeFetcher
 	OptimisticModeFetcher         blockchain.OptimisticModeFetcher
-	V1Alpha1ValidatorServer       eth.BeaconNodeValidatorServer
+	V1Alpha1ValidatorServer       V1Alpha1ValidatorServer
 	SyncChecker                   sync.Checker
 	CanonicalHistory              *stategen.CanonicalHistory
 	ExecutionPayloadReconstructor execution.ExecutionPayloadReconstructor
 	FinalizationFetcher           blockchain.FinalizationFetcher
 	BLSChangesPool                blstoexec.PoolManager
 	ForkchoiceFetcher             blockchain.ForkchoiceFetcher
+	StrictAmountValidation        bool
+	LenientJSONDecoding           bool
+	AcceptedForks                 map[int]bool
+	RequestTimeout                time.Duration
+	// SSZOnly disables publishing blocks as JSON, which saves the CPU spent on decoding it.
+	// Clients have to publish SSZ encoded blocks instead.
+	SSZOnly bool
+	// InclusionTimeout bounds how long a published block is waited for to become canonical
+	// when requested. Zero means one slot.
+	InclusionTimeout time.Duration
+	// ClockDisparityTolerance is how early a block can be published before the start of its slot without
+	// failing consensus validation for being from the future. Zero means not at all.
+	ClockDisparityTolerance time.Duration
+	// MaxConcurrentConsensusValidations bounds the number of blocks whose consensus validation,
+	// a full state transition, can run at the same time. Zero means no limit.
+	MaxConcurrentConsensusValidations int
+	consensusValidations              atomic.Int32
+	// now returns the current time for the checks of block timeliness. Nil means the wall clock,
+	// tests replace it with a fixed clock.
+	now func() time.Time
 }
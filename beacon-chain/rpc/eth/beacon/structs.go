@@ -1,18 +1,106 @@
 package beacon
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
 	"math/big"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	bytesutil2 "github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
-	"github.com/wealdtech/go-bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 )
 
+type PublishBlockResponse struct {
+	Data *PublishBlockResponseData `json:"data"`
+}
+
+type PublishBlockResponseData struct {
+	Slot string `json:"slot"`
+	Root string `json:"root"`
+	Head string `json:"head,omitempty"`
+}
+
+type PublishBlocksResponse struct {
+	Data []*PublishBlocksResult `json:"data"`
+}
+
+// PublishBlocksResult is the result of publishing one block of a batch. Code is the status code that
+// publishing the block on its own would have returned. Data is set when the block was published,
+// and Message describes the error otherwise.
+type PublishBlocksResult struct {
+	Code    int                       `json:"code"`
+	Data    *PublishBlockResponseData `json:"data,omitempty"`
+	Message string                    `json:"message,omitempty"`
+}
+
+// ConsensusValidationErrorJson is returned when a block fails consensus validation.
+// Failure describes the specific reason of the failure.
+type ConsensusValidationErrorJson struct {
+	Message string                      `json:"message"`
+	Code    int                         `json:"code"`
+	Failure *ConsensusValidationFailure `json:"failure"`
+}
+
+type ConsensusValidationFailure struct {
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// DetachedSignatureBlock is an unsigned block of any fork along with its separately produced signature.
+type DetachedSignatureBlock struct {
+	Block     json.RawMessage `json:"block"`
+	Signature string          `json:"signature"`
+}
+
+type GetBlockV2Response struct {
+	Version             string      `json:"version"`
+	ExecutionOptimistic bool        `json:"execution_optimistic"`
+	Finalized           bool        `json:"finalized"`
+	Data                interface{} `json:"data"`
+}
+
+type GetBlockAttesterSlashingsResponse struct {
+	Data                []AttesterSlashing `json:"data"`
+	ExecutionOptimistic bool               `json:"execution_optimistic"`
+	Finalized           bool               `json:"finalized"`
+	Total               string             `json:"total"`
+}
+
+type GetBlockProposerSlashingsResponse struct {
+	Data                []ProposerSlashing `json:"data"`
+	ExecutionOptimistic bool               `json:"execution_optimistic"`
+	Finalized           bool               `json:"finalized"`
+	Total               string             `json:"total"`
+}
+
+type GetBlockExecutionPayloadResponse struct {
+	Version             string      `json:"version"`
+	ExecutionOptimistic bool        `json:"execution_optimistic"`
+	Finalized           bool        `json:"finalized"`
+	Blinded             bool        `json:"blinded"`
+	Data                interface{} `json:"data"`
+}
+
+type GetForkResponse struct {
+	Data *Fork `json:"data"`
+}
+
+type Fork struct {
+	PreviousVersion string `json:"previous_version"`
+	CurrentVersion  string `json:"current_version"`
+	Epoch           string `json:"epoch"`
+}
+
 type SignedBeaconBlock struct {
 	Message   BeaconBlock `json:"message" validate:"required"`
 	Signature string      `json:"signature" validate:"required"`
@@ -338,45 +426,48 @@ type BlsToExecutionChange struct {
 }
 
 func (b *SignedBeaconBlock) ToGeneric() (*eth.GenericSignedBeaconBlock, error) {
-	sig, err := hexutil.Decode(b.Signature)
+	if b == nil {
+		return nil, errNilBlock
+	}
+	sig, err := decodeSignature("b.Signature", b.Signature)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Signature")
+		return nil, err
 	}
-	slot, err := strconv.ParseUint(b.Message.Slot, 10, 64)
+	slot, err := decodeSlot("b.Message.Slot", b.Message.Slot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Slot")
+		return nil, err
 	}
-	proposerIndex, err := strconv.ParseUint(b.Message.ProposerIndex, 10, 64)
+	proposerIndex, err := decodeValidatorIndex("b.Message.ProposerIndex", b.Message.ProposerIndex)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ProposerIndex")
+		return nil, err
 	}
-	parentRoot, err := hexutil.Decode(b.Message.ParentRoot)
+	parentRoot, err := decodeHex("b.Message.ParentRoot", b.Message.ParentRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ParentRoot")
+		return nil, err
 	}
-	stateRoot, err := hexutil.Decode(b.Message.StateRoot)
+	stateRoot, err := decodeHex("b.Message.StateRoot", b.Message.StateRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.StateRoot")
+		return nil, err
 	}
-	randaoReveal, err := hexutil.Decode(b.Message.Body.RandaoReveal)
+	randaoReveal, err := decodeHex("b.Message.Body.RandaoReveal", b.Message.Body.RandaoReveal)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.RandaoReveal")
+		return nil, err
 	}
-	depositRoot, err := hexutil.Decode(b.Message.Body.Eth1Data.DepositRoot)
+	depositRoot, err := decodeHex("b.Message.Body.Eth1Data.DepositRoot", b.Message.Body.Eth1Data.DepositRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositRoot")
+		return nil, err
 	}
-	depositCount, err := strconv.ParseUint(b.Message.Body.Eth1Data.DepositCount, 10, 64)
+	depositCount, err := decodeUint64("b.Message.Body.Eth1Data.DepositCount", b.Message.Body.Eth1Data.DepositCount)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositCount")
+		return nil, err
 	}
-	blockHash, err := hexutil.Decode(b.Message.Body.Eth1Data.BlockHash)
+	blockHash, err := decodeHex("b.Message.Body.Eth1Data.BlockHash", b.Message.Body.Eth1Data.BlockHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.BlockHash")
+		return nil, err
 	}
-	graffiti, err := hexutil.Decode(b.Message.Body.Graffiti)
+	graffiti, err := decodeHex("b.Message.Body.Graffiti", b.Message.Body.Graffiti)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Graffiti")
+		return nil, err
 	}
 	proposerSlashings, err := convertProposerSlashings(b.Message.Body.ProposerSlashings)
 	if err != nil {
@@ -426,45 +517,48 @@ func (b *SignedBeaconBlock) ToGeneric() (*eth.GenericSignedBeaconBlock, error) {
 }
 
 func (b *SignedBeaconBlockAltair) ToGeneric() (*eth.GenericSignedBeaconBlock, error) {
-	sig, err := hexutil.Decode(b.Signature)
+	if b == nil {
+		return nil, errNilBlock
+	}
+	sig, err := decodeSignature("b.Signature", b.Signature)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Signature")
+		return nil, err
 	}
-	slot, err := strconv.ParseUint(b.Message.Slot, 10, 64)
+	slot, err := decodeSlot("b.Message.Slot", b.Message.Slot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Slot")
+		return nil, err
 	}
-	proposerIndex, err := strconv.ParseUint(b.Message.ProposerIndex, 10, 64)
+	proposerIndex, err := decodeValidatorIndex("b.Message.ProposerIndex", b.Message.ProposerIndex)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ProposerIndex")
+		return nil, err
 	}
-	parentRoot, err := hexutil.Decode(b.Message.ParentRoot)
+	parentRoot, err := decodeHex("b.Message.ParentRoot", b.Message.ParentRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ParentRoot")
+		return nil, err
 	}
-	stateRoot, err := hexutil.Decode(b.Message.StateRoot)
+	stateRoot, err := decodeHex("b.Message.StateRoot", b.Message.StateRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.StateRoot")
+		return nil, err
 	}
-	randaoReveal, err := hexutil.Decode(b.Message.Body.RandaoReveal)
+	randaoReveal, err := decodeHex("b.Message.Body.RandaoReveal", b.Message.Body.RandaoReveal)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.RandaoReveal")
+		return nil, err
 	}
-	depositRoot, err := hexutil.Decode(b.Message.Body.Eth1Data.DepositRoot)
+	depositRoot, err := decodeHex("b.Message.Body.Eth1Data.DepositRoot", b.Message.Body.Eth1Data.DepositRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositRoot")
+		return nil, err
 	}
-	depositCount, err := strconv.ParseUint(b.Message.Body.Eth1Data.DepositCount, 10, 64)
+	depositCount, err := decodeUint64("b.Message.Body.Eth1Data.DepositCount", b.Message.Body.Eth1Data.DepositCount)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositCount")
+		return nil, err
 	}
-	blockHash, err := hexutil.Decode(b.Message.Body.Eth1Data.BlockHash)
+	blockHash, err := decodeHex("b.Message.Body.Eth1Data.BlockHash", b.Message.Body.Eth1Data.BlockHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.BlockHash")
+		return nil, err
 	}
-	graffiti, err := hexutil.Decode(b.Message.Body.Graffiti)
+	graffiti, err := decodeHex("b.Message.Body.Graffiti", b.Message.Body.Graffiti)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Graffiti")
+		return nil, err
 	}
 	proposerSlashings, err := convertProposerSlashings(b.Message.Body.ProposerSlashings)
 	if err != nil {
@@ -486,13 +580,13 @@ func (b *SignedBeaconBlockAltair) ToGeneric() (*eth.GenericSignedBeaconBlock, er
 	if err != nil {
 		return nil, err
 	}
-	syncCommitteeBits, err := bytesutil.FromHexString(b.Message.Body.SyncAggregate.SyncCommitteeBits)
+	syncCommitteeBits, err := decodeSyncCommitteeBits("b.Message.Body.SyncAggregate.SyncCommitteeBits", b.Message.Body.SyncAggregate.SyncCommitteeBits)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.SyncAggregate.SyncCommitteeBits")
+		return nil, err
 	}
-	syncCommitteeSig, err := hexutil.Decode(b.Message.Body.SyncAggregate.SyncCommitteeSignature)
+	syncCommitteeSig, err := decodeHex("b.Message.Body.SyncAggregate.SyncCommitteeSignature", b.Message.Body.SyncAggregate.SyncCommitteeSignature)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.SyncAggregate.SyncCommitteeSignature")
+		return nil, err
 	}
 
 	block := &eth.SignedBeaconBlockAltair{
@@ -526,45 +620,48 @@ func (b *SignedBeaconBlockAltair) ToGeneric() (*eth.GenericSignedBeaconBlock, er
 }
 
 func (b *SignedBeaconBlockBellatrix) ToGeneric() (*eth.GenericSignedBeaconBlock, error) {
-	sig, err := hexutil.Decode(b.Signature)
+	if b == nil {
+		return nil, errNilBlock
+	}
+	sig, err := decodeSignature("b.Signature", b.Signature)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Signature")
+		return nil, err
 	}
-	slot, err := strconv.ParseUint(b.Message.Slot, 10, 64)
+	slot, err := decodeSlot("b.Message.Slot", b.Message.Slot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Slot")
+		return nil, err
 	}
-	proposerIndex, err := strconv.ParseUint(b.Message.ProposerIndex, 10, 64)
+	proposerIndex, err := decodeValidatorIndex("b.Message.ProposerIndex", b.Message.ProposerIndex)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ProposerIndex")
+		return nil, err
 	}
-	parentRoot, err := hexutil.Decode(b.Message.ParentRoot)
+	parentRoot, err := decodeHex("b.Message.ParentRoot", b.Message.ParentRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ParentRoot")
+		return nil, err
 	}
-	stateRoot, err := hexutil.Decode(b.Message.StateRoot)
+	stateRoot, err := decodeHex("b.Message.StateRoot", b.Message.StateRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.StateRoot")
+		return nil, err
 	}
-	randaoReveal, err := hexutil.Decode(b.Message.Body.RandaoReveal)
+	randaoReveal, err := decodeHex("b.Message.Body.RandaoReveal", b.Message.Body.RandaoReveal)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.RandaoReveal")
+		return nil, err
 	}
-	depositRoot, err := hexutil.Decode(b.Message.Body.Eth1Data.DepositRoot)
+	depositRoot, err := decodeHex("b.Message.Body.Eth1Data.DepositRoot", b.Message.Body.Eth1Data.DepositRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositRoot")
+		return nil, err
 	}
-	depositCount, err := strconv.ParseUint(b.Message.Body.Eth1Data.DepositCount, 10, 64)
+	depositCount, err := decodeUint64("b.Message.Body.Eth1Data.DepositCount", b.Message.Body.Eth1Data.DepositCount)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositCount")
+		return nil, err
 	}
-	blockHash, err := hexutil.Decode(b.Message.Body.Eth1Data.BlockHash)
+	blockHash, err := decodeHex("b.Message.Body.Eth1Data.BlockHash", b.Message.Body.Eth1Data.BlockHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.BlockHash")
+		return nil, err
 	}
-	graffiti, err := hexutil.Decode(b.Message.Body.Graffiti)
+	graffiti, err := decodeHex("b.Message.Body.Graffiti", b.Message.Body.Graffiti)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Graffiti")
+		return nil, err
 	}
 	proposerSlashings, err := convertProposerSlashings(b.Message.Body.ProposerSlashings)
 	if err != nil {
@@ -586,72 +683,69 @@ func (b *SignedBeaconBlockBellatrix) ToGeneric() (*eth.GenericSignedBeaconBlock,
 	if err != nil {
 		return nil, err
 	}
-	syncCommitteeBits, err := bytesutil.FromHexString(b.Message.Body.SyncAggregate.SyncCommitteeBits)
+	syncCommitteeBits, err := decodeSyncCommitteeBits("b.Message.Body.SyncAggregate.SyncCommitteeBits", b.Message.Body.SyncAggregate.SyncCommitteeBits)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.SyncAggregate.SyncCommitteeBits")
+		return nil, err
 	}
-	syncCommitteeSig, err := hexutil.Decode(b.Message.Body.SyncAggregate.SyncCommitteeSignature)
+	syncCommitteeSig, err := decodeHex("b.Message.Body.SyncAggregate.SyncCommitteeSignature", b.Message.Body.SyncAggregate.SyncCommitteeSignature)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.SyncAggregate.SyncCommitteeSignature")
+		return nil, err
 	}
-	payloadParentHash, err := hexutil.Decode(b.Message.Body.ExecutionPayload.ParentHash)
+	payloadParentHash, err := decodeHex("b.Message.Body.ExecutionPayload.ParentHash", b.Message.Body.ExecutionPayload.ParentHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.ParentHash")
+		return nil, err
 	}
-	payloadFeeRecipient, err := hexutil.Decode(b.Message.Body.ExecutionPayload.FeeRecipient)
+	payloadFeeRecipient, err := decodeHex("b.Message.Body.ExecutionPayload.FeeRecipient", b.Message.Body.ExecutionPayload.FeeRecipient)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.FeeRecipient")
+		return nil, err
 	}
-	payloadStateRoot, err := hexutil.Decode(b.Message.Body.ExecutionPayload.StateRoot)
+	payloadStateRoot, err := decodeHex("b.Message.Body.ExecutionPayload.StateRoot", b.Message.Body.ExecutionPayload.StateRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.StateRoot")
+		return nil, err
 	}
-	payloadReceiptsRoot, err := hexutil.Decode(b.Message.Body.ExecutionPayload.ReceiptsRoot)
+	payloadReceiptsRoot, err := decodeHex("b.Message.Body.ExecutionPayload.ReceiptsRoot", b.Message.Body.ExecutionPayload.ReceiptsRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.ReceiptsRoot")
+		return nil, err
 	}
-	payloadLogsBloom, err := hexutil.Decode(b.Message.Body.ExecutionPayload.LogsBloom)
+	payloadLogsBloom, err := decodeLogsBloom("b.Message.Body.ExecutionPayload.LogsBloom", b.Message.Body.ExecutionPayload.LogsBloom)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.LogsBloom")
+		return nil, err
 	}
-	payloadPrevRandao, err := hexutil.Decode(b.Message.Body.ExecutionPayload.PrevRandao)
+	payloadPrevRandao, err := decodeHex("b.Message.Body.ExecutionPayload.PrevRandao", b.Message.Body.ExecutionPayload.PrevRandao)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.PrevRandao")
+		return nil, err
 	}
-	payloadBlockNumber, err := strconv.ParseUint(b.Message.Body.ExecutionPayload.BlockNumber, 10, 64)
+	payloadBlockNumber, err := decodeUint64("b.Message.Body.ExecutionPayload.BlockNumber", b.Message.Body.ExecutionPayload.BlockNumber)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.BlockNumber")
+		return nil, err
 	}
-	payloadGasLimit, err := strconv.ParseUint(b.Message.Body.ExecutionPayload.GasLimit, 10, 64)
+	payloadGasLimit, err := decodeUint64("b.Message.Body.ExecutionPayload.GasLimit", b.Message.Body.ExecutionPayload.GasLimit)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.GasLimit")
+		return nil, err
 	}
-	payloadGasUsed, err := strconv.ParseUint(b.Message.Body.ExecutionPayload.GasUsed, 10, 64)
+	payloadGasUsed, err := decodeUint64("b.Message.Body.ExecutionPayload.GasUsed", b.Message.Body.ExecutionPayload.GasUsed)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.GasUsed")
+		return nil, err
 	}
-	payloadTimestamp, err := strconv.ParseUint(b.Message.Body.ExecutionPayload.Timestamp, 10, 64)
+	payloadTimestamp, err := decodeUint64("b.Message.Body.ExecutionPayload.Timestamp", b.Message.Body.ExecutionPayload.Timestamp)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.Timestamp")
+		return nil, err
 	}
-	payloadExtraData, err := hexutil.Decode(b.Message.Body.ExecutionPayload.ExtraData)
+	payloadExtraData, err := decodeExtraData("b.Message.Body.ExecutionPayload.ExtraData", b.Message.Body.ExecutionPayload.ExtraData)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.ExtraData")
+		return nil, err
 	}
-	payloadBaseFeePerGas, err := uint256ToHex(b.Message.Body.ExecutionPayload.BaseFeePerGas)
+	payloadBaseFeePerGas, err := decodeUint256("b.Message.Body.ExecutionPayload.BaseFeePerGas", b.Message.Body.ExecutionPayload.BaseFeePerGas)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.BaseFeePerGas")
+		return nil, err
 	}
-	payloadBlockHash, err := hexutil.Decode(b.Message.Body.ExecutionPayload.BlockHash)
+	payloadBlockHash, err := decodeHex("b.Message.Body.ExecutionPayload.BlockHash", b.Message.Body.ExecutionPayload.BlockHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.BlockHash")
+		return nil, err
 	}
-	payloadTxs := make([][]byte, len(b.Message.Body.ExecutionPayload.Transactions))
-	for i, tx := range b.Message.Body.ExecutionPayload.Transactions {
-		payloadTxs[i], err = hexutil.Decode(tx)
-		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ExecutionPayload.Transactions[%d]", i)
-		}
+	payloadTxs, err := decodeTransactions(b.Message.Body.ExecutionPayload.Transactions)
+	if err != nil {
+		return nil, err
 	}
 
 	block := &eth.SignedBeaconBlockBellatrix{
@@ -701,45 +795,48 @@ func (b *SignedBeaconBlockBellatrix) ToGeneric() (*eth.GenericSignedBeaconBlock,
 }
 
 func (b *SignedBlindedBeaconBlockBellatrix) ToGeneric() (*eth.GenericSignedBeaconBlock, error) {
-	sig, err := hexutil.Decode(b.Signature)
+	if b == nil {
+		return nil, errNilBlock
+	}
+	sig, err := decodeSignature("b.Signature", b.Signature)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Signature")
+		return nil, err
 	}
-	slot, err := strconv.ParseUint(b.Message.Slot, 10, 64)
+	slot, err := decodeSlot("b.Message.Slot", b.Message.Slot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Slot")
+		return nil, err
 	}
-	proposerIndex, err := strconv.ParseUint(b.Message.ProposerIndex, 10, 64)
+	proposerIndex, err := decodeValidatorIndex("b.Message.ProposerIndex", b.Message.ProposerIndex)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ProposerIndex")
+		return nil, err
 	}
-	parentRoot, err := hexutil.Decode(b.Message.ParentRoot)
+	parentRoot, err := decodeHex("b.Message.ParentRoot", b.Message.ParentRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ParentRoot")
+		return nil, err
 	}
-	stateRoot, err := hexutil.Decode(b.Message.StateRoot)
+	stateRoot, err := decodeHex("b.Message.StateRoot", b.Message.StateRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.StateRoot")
+		return nil, err
 	}
-	randaoReveal, err := hexutil.Decode(b.Message.Body.RandaoReveal)
+	randaoReveal, err := decodeHex("b.Message.Body.RandaoReveal", b.Message.Body.RandaoReveal)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.RandaoReveal")
+		return nil, err
 	}
-	depositRoot, err := hexutil.Decode(b.Message.Body.Eth1Data.DepositRoot)
+	depositRoot, err := decodeHex("b.Message.Body.Eth1Data.DepositRoot", b.Message.Body.Eth1Data.DepositRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositRoot")
+		return nil, err
 	}
-	depositCount, err := strconv.ParseUint(b.Message.Body.Eth1Data.DepositCount, 10, 64)
+	depositCount, err := decodeUint64("b.Message.Body.Eth1Data.DepositCount", b.Message.Body.Eth1Data.DepositCount)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositCount")
+		return nil, err
 	}
-	blockHash, err := hexutil.Decode(b.Message.Body.Eth1Data.BlockHash)
+	blockHash, err := decodeHex("b.Message.Body.Eth1Data.BlockHash", b.Message.Body.Eth1Data.BlockHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.BlockHash")
+		return nil, err
 	}
-	graffiti, err := hexutil.Decode(b.Message.Body.Graffiti)
+	graffiti, err := decodeHex("b.Message.Body.Graffiti", b.Message.Body.Graffiti)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Graffiti")
+		return nil, err
 	}
 	proposerSlashings, err := convertProposerSlashings(b.Message.Body.ProposerSlashings)
 	if err != nil {
@@ -761,69 +858,69 @@ func (b *SignedBlindedBeaconBlockBellatrix) ToGeneric() (*eth.GenericSignedBeaco
 	if err != nil {
 		return nil, err
 	}
-	syncCommitteeBits, err := bytesutil.FromHexString(b.Message.Body.SyncAggregate.SyncCommitteeBits)
+	syncCommitteeBits, err := decodeSyncCommitteeBits("b.Message.Body.SyncAggregate.SyncCommitteeBits", b.Message.Body.SyncAggregate.SyncCommitteeBits)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.SyncAggregate.SyncCommitteeBits")
+		return nil, err
 	}
-	syncCommitteeSig, err := hexutil.Decode(b.Message.Body.SyncAggregate.SyncCommitteeSignature)
+	syncCommitteeSig, err := decodeHex("b.Message.Body.SyncAggregate.SyncCommitteeSignature", b.Message.Body.SyncAggregate.SyncCommitteeSignature)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.SyncAggregate.SyncCommitteeSignature")
+		return nil, err
 	}
-	payloadParentHash, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.ParentHash)
+	payloadParentHash, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.ParentHash", b.Message.Body.ExecutionPayloadHeader.ParentHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.ParentHash")
+		return nil, err
 	}
-	payloadFeeRecipient, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.FeeRecipient)
+	payloadFeeRecipient, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.FeeRecipient", b.Message.Body.ExecutionPayloadHeader.FeeRecipient)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.FeeRecipient")
+		return nil, err
 	}
-	payloadStateRoot, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.StateRoot)
+	payloadStateRoot, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.StateRoot", b.Message.Body.ExecutionPayloadHeader.StateRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.StateRoot")
+		return nil, err
 	}
-	payloadReceiptsRoot, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.ReceiptsRoot)
+	payloadReceiptsRoot, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.ReceiptsRoot", b.Message.Body.ExecutionPayloadHeader.ReceiptsRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.ReceiptsRoot")
+		return nil, err
 	}
-	payloadLogsBloom, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.LogsBloom)
+	payloadLogsBloom, err := decodeLogsBloom("b.Message.Body.ExecutionPayloadHeader.LogsBloom", b.Message.Body.ExecutionPayloadHeader.LogsBloom)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.LogsBloom")
+		return nil, err
 	}
-	payloadPrevRandao, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.PrevRandao)
+	payloadPrevRandao, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.PrevRandao", b.Message.Body.ExecutionPayloadHeader.PrevRandao)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.PrevRandao")
+		return nil, err
 	}
-	payloadBlockNumber, err := strconv.ParseUint(b.Message.Body.ExecutionPayloadHeader.BlockNumber, 10, 64)
+	payloadBlockNumber, err := decodeUint64("b.Message.Body.ExecutionPayloadHeader.BlockNumber", b.Message.Body.ExecutionPayloadHeader.BlockNumber)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.BlockNumber")
+		return nil, err
 	}
-	payloadGasLimit, err := strconv.ParseUint(b.Message.Body.ExecutionPayloadHeader.GasLimit, 10, 64)
+	payloadGasLimit, err := decodeUint64("b.Message.Body.ExecutionPayloadHeader.GasLimit", b.Message.Body.ExecutionPayloadHeader.GasLimit)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.GasLimit")
+		return nil, err
 	}
-	payloadGasUsed, err := strconv.ParseUint(b.Message.Body.ExecutionPayloadHeader.GasUsed, 10, 64)
+	payloadGasUsed, err := decodeUint64("b.Message.Body.ExecutionPayloadHeader.GasUsed", b.Message.Body.ExecutionPayloadHeader.GasUsed)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.GasUsed")
+		return nil, err
 	}
-	payloadTimestamp, err := strconv.ParseUint(b.Message.Body.ExecutionPayloadHeader.Timestamp, 10, 64)
+	payloadTimestamp, err := decodeUint64("b.Message.Body.ExecutionPayloadHeader.Timestamp", b.Message.Body.ExecutionPayloadHeader.Timestamp)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.Timestamp")
+		return nil, err
 	}
-	payloadExtraData, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.ExtraData)
+	payloadExtraData, err := decodeExtraData("b.Message.Body.ExecutionPayloadHeader.ExtraData", b.Message.Body.ExecutionPayloadHeader.ExtraData)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.ExtraData")
+		return nil, err
 	}
-	payloadBaseFeePerGas, err := uint256ToHex(b.Message.Body.ExecutionPayloadHeader.BaseFeePerGas)
+	payloadBaseFeePerGas, err := decodeUint256("b.Message.Body.ExecutionPayloadHeader.BaseFeePerGas", b.Message.Body.ExecutionPayloadHeader.BaseFeePerGas)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.BaseFeePerGas")
+		return nil, err
 	}
-	payloadBlockHash, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.BlockHash)
+	payloadBlockHash, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.BlockHash", b.Message.Body.ExecutionPayloadHeader.BlockHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.BlockHash")
+		return nil, err
 	}
-	payloadTxsRoot, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.TransactionsRoot)
+	payloadTxsRoot, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.TransactionsRoot", b.Message.Body.ExecutionPayloadHeader.TransactionsRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.TransactionsRoot")
+		return nil, err
 	}
 
 	block := &eth.SignedBlindedBeaconBlockBellatrix{
@@ -873,45 +970,48 @@ func (b *SignedBlindedBeaconBlockBellatrix) ToGeneric() (*eth.GenericSignedBeaco
 }
 
 func (b *SignedBeaconBlockCapella) ToGeneric() (*eth.GenericSignedBeaconBlock, error) {
-	sig, err := hexutil.Decode(b.Signature)
+	if b == nil {
+		return nil, errNilBlock
+	}
+	sig, err := decodeSignature("b.Signature", b.Signature)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Signature")
+		return nil, err
 	}
-	slot, err := strconv.ParseUint(b.Message.Slot, 10, 64)
+	slot, err := decodeSlot("b.Message.Slot", b.Message.Slot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Slot")
+		return nil, err
 	}
-	proposerIndex, err := strconv.ParseUint(b.Message.ProposerIndex, 10, 64)
+	proposerIndex, err := decodeValidatorIndex("b.Message.ProposerIndex", b.Message.ProposerIndex)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ProposerIndex")
+		return nil, err
 	}
-	parentRoot, err := hexutil.Decode(b.Message.ParentRoot)
+	parentRoot, err := decodeHex("b.Message.ParentRoot", b.Message.ParentRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ParentRoot")
+		return nil, err
 	}
-	stateRoot, err := hexutil.Decode(b.Message.StateRoot)
+	stateRoot, err := decodeHex("b.Message.StateRoot", b.Message.StateRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.StateRoot")
+		return nil, err
 	}
-	randaoReveal, err := hexutil.Decode(b.Message.Body.RandaoReveal)
+	randaoReveal, err := decodeHex("b.Message.Body.RandaoReveal", b.Message.Body.RandaoReveal)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.RandaoReveal")
+		return nil, err
 	}
-	depositRoot, err := hexutil.Decode(b.Message.Body.Eth1Data.DepositRoot)
+	depositRoot, err := decodeHex("b.Message.Body.Eth1Data.DepositRoot", b.Message.Body.Eth1Data.DepositRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositRoot")
+		return nil, err
 	}
-	depositCount, err := strconv.ParseUint(b.Message.Body.Eth1Data.DepositCount, 10, 64)
+	depositCount, err := decodeUint64("b.Message.Body.Eth1Data.DepositCount", b.Message.Body.Eth1Data.DepositCount)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositCount")
+		return nil, err
 	}
-	blockHash, err := hexutil.Decode(b.Message.Body.Eth1Data.BlockHash)
+	blockHash, err := decodeHex("b.Message.Body.Eth1Data.BlockHash", b.Message.Body.Eth1Data.BlockHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.BlockHash")
+		return nil, err
 	}
-	graffiti, err := hexutil.Decode(b.Message.Body.Graffiti)
+	graffiti, err := decodeHex("b.Message.Body.Graffiti", b.Message.Body.Graffiti)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Graffiti")
+		return nil, err
 	}
 	proposerSlashings, err := convertProposerSlashings(b.Message.Body.ProposerSlashings)
 	if err != nil {
@@ -933,90 +1033,87 @@ func (b *SignedBeaconBlockCapella) ToGeneric() (*eth.GenericSignedBeaconBlock, e
 	if err != nil {
 		return nil, err
 	}
-	syncCommitteeBits, err := bytesutil.FromHexString(b.Message.Body.SyncAggregate.SyncCommitteeBits)
+	syncCommitteeBits, err := decodeSyncCommitteeBits("b.Message.Body.SyncAggregate.SyncCommitteeBits", b.Message.Body.SyncAggregate.SyncCommitteeBits)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.SyncAggregate.SyncCommitteeBits")
+		return nil, err
 	}
-	syncCommitteeSig, err := hexutil.Decode(b.Message.Body.SyncAggregate.SyncCommitteeSignature)
+	syncCommitteeSig, err := decodeHex("b.Message.Body.SyncAggregate.SyncCommitteeSignature", b.Message.Body.SyncAggregate.SyncCommitteeSignature)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.SyncAggregate.SyncCommitteeSignature")
+		return nil, err
 	}
-	payloadParentHash, err := hexutil.Decode(b.Message.Body.ExecutionPayload.ParentHash)
+	payloadParentHash, err := decodeHex("b.Message.Body.ExecutionPayload.ParentHash", b.Message.Body.ExecutionPayload.ParentHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.ParentHash")
+		return nil, err
 	}
-	payloadFeeRecipient, err := hexutil.Decode(b.Message.Body.ExecutionPayload.FeeRecipient)
+	payloadFeeRecipient, err := decodeHex("b.Message.Body.ExecutionPayload.FeeRecipient", b.Message.Body.ExecutionPayload.FeeRecipient)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.FeeRecipient")
+		return nil, err
 	}
-	payloadStateRoot, err := hexutil.Decode(b.Message.Body.ExecutionPayload.StateRoot)
+	payloadStateRoot, err := decodeHex("b.Message.Body.ExecutionPayload.StateRoot", b.Message.Body.ExecutionPayload.StateRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.StateRoot")
+		return nil, err
 	}
-	payloadReceiptsRoot, err := hexutil.Decode(b.Message.Body.ExecutionPayload.ReceiptsRoot)
+	payloadReceiptsRoot, err := decodeHex("b.Message.Body.ExecutionPayload.ReceiptsRoot", b.Message.Body.ExecutionPayload.ReceiptsRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.ReceiptsRoot")
+		return nil, err
 	}
-	payloadLogsBloom, err := hexutil.Decode(b.Message.Body.ExecutionPayload.LogsBloom)
+	payloadLogsBloom, err := decodeLogsBloom("b.Message.Body.ExecutionPayload.LogsBloom", b.Message.Body.ExecutionPayload.LogsBloom)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.LogsBloom")
+		return nil, err
 	}
-	payloadPrevRandao, err := hexutil.Decode(b.Message.Body.ExecutionPayload.PrevRandao)
+	payloadPrevRandao, err := decodeHex("b.Message.Body.ExecutionPayload.PrevRandao", b.Message.Body.ExecutionPayload.PrevRandao)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.PrevRandao")
+		return nil, err
 	}
-	payloadBlockNumber, err := strconv.ParseUint(b.Message.Body.ExecutionPayload.BlockNumber, 10, 64)
+	payloadBlockNumber, err := decodeUint64("b.Message.Body.ExecutionPayload.BlockNumber", b.Message.Body.ExecutionPayload.BlockNumber)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.BlockNumber")
+		return nil, err
 	}
-	payloadGasLimit, err := strconv.ParseUint(b.Message.Body.ExecutionPayload.GasLimit, 10, 64)
+	payloadGasLimit, err := decodeUint64("b.Message.Body.ExecutionPayload.GasLimit", b.Message.Body.ExecutionPayload.GasLimit)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.GasLimit")
+		return nil, err
 	}
-	payloadGasUsed, err := strconv.ParseUint(b.Message.Body.ExecutionPayload.GasUsed, 10, 64)
+	payloadGasUsed, err := decodeUint64("b.Message.Body.ExecutionPayload.GasUsed", b.Message.Body.ExecutionPayload.GasUsed)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.GasUsed")
+		return nil, err
 	}
-	payloadTimestamp, err := strconv.ParseUint(b.Message.Body.ExecutionPayload.Timestamp, 10, 64)
+	payloadTimestamp, err := decodeUint64("b.Message.Body.ExecutionPayload.Timestamp", b.Message.Body.ExecutionPayload.Timestamp)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.Timestamp")
+		return nil, err
 	}
-	payloadExtraData, err := hexutil.Decode(b.Message.Body.ExecutionPayload.ExtraData)
+	payloadExtraData, err := decodeExtraData("b.Message.Body.ExecutionPayload.ExtraData", b.Message.Body.ExecutionPayload.ExtraData)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.ExtraData")
+		return nil, err
 	}
-	payloadBaseFeePerGas, err := uint256ToHex(b.Message.Body.ExecutionPayload.BaseFeePerGas)
+	payloadBaseFeePerGas, err := decodeUint256("b.Message.Body.ExecutionPayload.BaseFeePerGas", b.Message.Body.ExecutionPayload.BaseFeePerGas)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.BaseFeePerGas")
+		return nil, err
 	}
-	payloadBlockHash, err := hexutil.Decode(b.Message.Body.ExecutionPayload.BlockHash)
+	payloadBlockHash, err := decodeHex("b.Message.Body.ExecutionPayload.BlockHash", b.Message.Body.ExecutionPayload.BlockHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayload.BlockHash")
+		return nil, err
 	}
-	txs := make([][]byte, len(b.Message.Body.ExecutionPayload.Transactions))
-	for i, tx := range b.Message.Body.ExecutionPayload.Transactions {
-		txs[i], err = hexutil.Decode(tx)
-		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ExecutionPayload.Transactions[%d]", i)
-		}
+	txs, err := decodeTransactions(b.Message.Body.ExecutionPayload.Transactions)
+	if err != nil {
+		return nil, err
 	}
 	withdrawals := make([]*enginev1.Withdrawal, len(b.Message.Body.ExecutionPayload.Withdrawals))
 	for i, w := range b.Message.Body.ExecutionPayload.Withdrawals {
-		withdrawalIndex, err := strconv.ParseUint(w.WithdrawalIndex, 10, 64)
+		withdrawalIndex, err := decodeUint64(fmt.Sprintf("b.Message.Body.ExecutionPayload.Withdrawals[%d].WithdrawalIndex", i), w.WithdrawalIndex)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ExecutionPayload.Withdrawals[%d].WithdrawalIndex", i)
+			return nil, err
 		}
-		validatorIndex, err := strconv.ParseUint(w.ValidatorIndex, 10, 64)
+		validatorIndex, err := decodeValidatorIndex(fmt.Sprintf("b.Message.Body.ExecutionPayload.Withdrawals[%d].ValidatorIndex", i), w.ValidatorIndex)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ExecutionPayload.Withdrawals[%d].ValidatorIndex", i)
+			return nil, err
 		}
-		address, err := hexutil.Decode(w.ExecutionAddress)
+		address, err := decodeHex(fmt.Sprintf("b.Message.Body.ExecutionPayload.Withdrawals[%d].ExecutionAddress", i), w.ExecutionAddress)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ExecutionPayload.Withdrawals[%d].ExecutionAddress", i)
+			return nil, err
 		}
-		amount, err := strconv.ParseUint(w.Amount, 10, 64)
+		amount, err := decodeUint64(fmt.Sprintf("b.Message.Body.ExecutionPayload.Withdrawals[%d].Amount", i), w.Amount)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ExecutionPayload.Withdrawals[%d].Amount", i)
+			return nil, err
 		}
 		withdrawals[i] = &enginev1.Withdrawal{
 			Index:          withdrawalIndex,
@@ -1079,45 +1176,48 @@ func (b *SignedBeaconBlockCapella) ToGeneric() (*eth.GenericSignedBeaconBlock, e
 }
 
 func (b *SignedBlindedBeaconBlockCapella) ToGeneric() (*eth.GenericSignedBeaconBlock, error) {
-	sig, err := hexutil.Decode(b.Signature)
+	if b == nil {
+		return nil, errNilBlock
+	}
+	sig, err := decodeSignature("b.Signature", b.Signature)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Signature")
+		return nil, err
 	}
-	slot, err := strconv.ParseUint(b.Message.Slot, 10, 64)
+	slot, err := decodeSlot("b.Message.Slot", b.Message.Slot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Slot")
+		return nil, err
 	}
-	proposerIndex, err := strconv.ParseUint(b.Message.ProposerIndex, 10, 64)
+	proposerIndex, err := decodeValidatorIndex("b.Message.ProposerIndex", b.Message.ProposerIndex)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ProposerIndex")
+		return nil, err
 	}
-	parentRoot, err := hexutil.Decode(b.Message.ParentRoot)
+	parentRoot, err := decodeHex("b.Message.ParentRoot", b.Message.ParentRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.ParentRoot")
+		return nil, err
 	}
-	stateRoot, err := hexutil.Decode(b.Message.StateRoot)
+	stateRoot, err := decodeHex("b.Message.StateRoot", b.Message.StateRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.StateRoot")
+		return nil, err
 	}
-	randaoReveal, err := hexutil.Decode(b.Message.Body.RandaoReveal)
+	randaoReveal, err := decodeHex("b.Message.Body.RandaoReveal", b.Message.Body.RandaoReveal)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.RandaoReveal")
+		return nil, err
 	}
-	depositRoot, err := hexutil.Decode(b.Message.Body.Eth1Data.DepositRoot)
+	depositRoot, err := decodeHex("b.Message.Body.Eth1Data.DepositRoot", b.Message.Body.Eth1Data.DepositRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositRoot")
+		return nil, err
 	}
-	depositCount, err := strconv.ParseUint(b.Message.Body.Eth1Data.DepositCount, 10, 64)
+	depositCount, err := decodeUint64("b.Message.Body.Eth1Data.DepositCount", b.Message.Body.Eth1Data.DepositCount)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.DepositCount")
+		return nil, err
 	}
-	blockHash, err := hexutil.Decode(b.Message.Body.Eth1Data.BlockHash)
+	blockHash, err := decodeHex("b.Message.Body.Eth1Data.BlockHash", b.Message.Body.Eth1Data.BlockHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Eth1Data.BlockHash")
+		return nil, err
 	}
-	graffiti, err := hexutil.Decode(b.Message.Body.Graffiti)
+	graffiti, err := decodeHex("b.Message.Body.Graffiti", b.Message.Body.Graffiti)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.Graffiti")
+		return nil, err
 	}
 	proposerSlashings, err := convertProposerSlashings(b.Message.Body.ProposerSlashings)
 	if err != nil {
@@ -1139,73 +1239,73 @@ func (b *SignedBlindedBeaconBlockCapella) ToGeneric() (*eth.GenericSignedBeaconB
 	if err != nil {
 		return nil, err
 	}
-	syncCommitteeBits, err := bytesutil.FromHexString(b.Message.Body.SyncAggregate.SyncCommitteeBits)
+	syncCommitteeBits, err := decodeSyncCommitteeBits("b.Message.Body.SyncAggregate.SyncCommitteeBits", b.Message.Body.SyncAggregate.SyncCommitteeBits)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.SyncAggregate.SyncCommitteeBits")
+		return nil, err
 	}
-	syncCommitteeSig, err := hexutil.Decode(b.Message.Body.SyncAggregate.SyncCommitteeSignature)
+	syncCommitteeSig, err := decodeHex("b.Message.Body.SyncAggregate.SyncCommitteeSignature", b.Message.Body.SyncAggregate.SyncCommitteeSignature)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.SyncAggregate.SyncCommitteeSignature")
+		return nil, err
 	}
-	payloadParentHash, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.ParentHash)
+	payloadParentHash, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.ParentHash", b.Message.Body.ExecutionPayloadHeader.ParentHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.ParentHash")
+		return nil, err
 	}
-	payloadFeeRecipient, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.FeeRecipient)
+	payloadFeeRecipient, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.FeeRecipient", b.Message.Body.ExecutionPayloadHeader.FeeRecipient)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.FeeRecipient")
+		return nil, err
 	}
-	payloadStateRoot, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.StateRoot)
+	payloadStateRoot, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.StateRoot", b.Message.Body.ExecutionPayloadHeader.StateRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.StateRoot")
+		return nil, err
 	}
-	payloadReceiptsRoot, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.ReceiptsRoot)
+	payloadReceiptsRoot, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.ReceiptsRoot", b.Message.Body.ExecutionPayloadHeader.ReceiptsRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.ReceiptsRoot")
+		return nil, err
 	}
-	payloadLogsBloom, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.LogsBloom)
+	payloadLogsBloom, err := decodeLogsBloom("b.Message.Body.ExecutionPayloadHeader.LogsBloom", b.Message.Body.ExecutionPayloadHeader.LogsBloom)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.LogsBloom")
+		return nil, err
 	}
-	payloadPrevRandao, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.PrevRandao)
+	payloadPrevRandao, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.PrevRandao", b.Message.Body.ExecutionPayloadHeader.PrevRandao)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.PrevRandao")
+		return nil, err
 	}
-	payloadBlockNumber, err := strconv.ParseUint(b.Message.Body.ExecutionPayloadHeader.BlockNumber, 10, 64)
+	payloadBlockNumber, err := decodeUint64("b.Message.Body.ExecutionPayloadHeader.BlockNumber", b.Message.Body.ExecutionPayloadHeader.BlockNumber)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.BlockNumber")
+		return nil, err
 	}
-	payloadGasLimit, err := strconv.ParseUint(b.Message.Body.ExecutionPayloadHeader.GasLimit, 10, 64)
+	payloadGasLimit, err := decodeUint64("b.Message.Body.ExecutionPayloadHeader.GasLimit", b.Message.Body.ExecutionPayloadHeader.GasLimit)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.GasLimit")
+		return nil, err
 	}
-	payloadGasUsed, err := strconv.ParseUint(b.Message.Body.ExecutionPayloadHeader.GasUsed, 10, 64)
+	payloadGasUsed, err := decodeUint64("b.Message.Body.ExecutionPayloadHeader.GasUsed", b.Message.Body.ExecutionPayloadHeader.GasUsed)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.GasUsed")
+		return nil, err
 	}
-	payloadTimestamp, err := strconv.ParseUint(b.Message.Body.ExecutionPayloadHeader.Timestamp, 10, 64)
+	payloadTimestamp, err := decodeUint64("b.Message.Body.ExecutionPayloadHeader.Timestamp", b.Message.Body.ExecutionPayloadHeader.Timestamp)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.Timestamp")
+		return nil, err
 	}
-	payloadExtraData, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.ExtraData)
+	payloadExtraData, err := decodeExtraData("b.Message.Body.ExecutionPayloadHeader.ExtraData", b.Message.Body.ExecutionPayloadHeader.ExtraData)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.ExtraData")
+		return nil, err
 	}
-	payloadBaseFeePerGas, err := uint256ToHex(b.Message.Body.ExecutionPayloadHeader.BaseFeePerGas)
+	payloadBaseFeePerGas, err := decodeUint256("b.Message.Body.ExecutionPayloadHeader.BaseFeePerGas", b.Message.Body.ExecutionPayloadHeader.BaseFeePerGas)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.BaseFeePerGas")
+		return nil, err
 	}
-	payloadBlockHash, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.BlockHash)
+	payloadBlockHash, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.BlockHash", b.Message.Body.ExecutionPayloadHeader.BlockHash)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.BlockHash")
+		return nil, err
 	}
-	payloadTxsRoot, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.TransactionsRoot)
+	payloadTxsRoot, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.TransactionsRoot", b.Message.Body.ExecutionPayloadHeader.TransactionsRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.TransactionsRoot")
+		return nil, err
 	}
-	payloadWithdrawalsRoot, err := hexutil.Decode(b.Message.Body.ExecutionPayloadHeader.WithdrawalsRoot)
+	payloadWithdrawalsRoot, err := decodeHex("b.Message.Body.ExecutionPayloadHeader.WithdrawalsRoot", b.Message.Body.ExecutionPayloadHeader.WithdrawalsRoot)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not decode b.Message.Body.ExecutionPayloadHeader.WithdrawalsRoot")
+		return nil, err
 	}
 	blsChanges, err := convertBlsChanges(b.Message.Body.BlsToExecutionChanges)
 	if err != nil {
@@ -1260,60 +1360,184 @@ func (b *SignedBlindedBeaconBlockCapella) ToGeneric() (*eth.GenericSignedBeaconB
 	return &eth.GenericSignedBeaconBlock{Block: &eth.GenericSignedBeaconBlock_BlindedCapella{BlindedCapella: block}}, nil
 }
 
+// genericBlock is a JSON signed block that can be converted into its consensus representation.
+type genericBlock interface {
+	ToGeneric() (*eth.GenericSignedBeaconBlock, error)
+}
+
+// marshalGeneric converts the block to its consensus representation and returns the SSZ encoding of the
+// fork's signed block, which pick gets out of it.
+func marshalGeneric[T interface{ MarshalSSZ() ([]byte, error) }](b genericBlock, pick func(*eth.GenericSignedBeaconBlock) T) ([]byte, error) {
+	blk, err := b.ToGeneric()
+	if err != nil {
+		return nil, err
+	}
+	return pick(blk).MarshalSSZ()
+}
+
+// hashGeneric converts the block to its consensus representation and returns the root of its message.
+func hashGeneric(b genericBlock) ([32]byte, error) {
+	blk, err := b.ToGeneric()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return genericBlockRoot(blk)
+}
+
+func (b *SignedBeaconBlock) MarshalSSZ() ([]byte, error) {
+	return marshalGeneric(b, (*eth.GenericSignedBeaconBlock).GetPhase0)
+}
+
+func (b *SignedBeaconBlock) HashTreeRoot() ([32]byte, error) {
+	return hashGeneric(b)
+}
+
+func (b *SignedBeaconBlockAltair) MarshalSSZ() ([]byte, error) {
+	return marshalGeneric(b, (*eth.GenericSignedBeaconBlock).GetAltair)
+}
+
+func (b *SignedBeaconBlockAltair) HashTreeRoot() ([32]byte, error) {
+	return hashGeneric(b)
+}
+
+func (b *SignedBeaconBlockBellatrix) MarshalSSZ() ([]byte, error) {
+	return marshalGeneric(b, (*eth.GenericSignedBeaconBlock).GetBellatrix)
+}
+
+func (b *SignedBeaconBlockBellatrix) HashTreeRoot() ([32]byte, error) {
+	return hashGeneric(b)
+}
+
+func (b *SignedBlindedBeaconBlockBellatrix) MarshalSSZ() ([]byte, error) {
+	return marshalGeneric(b, (*eth.GenericSignedBeaconBlock).GetBlindedBellatrix)
+}
+
+func (b *SignedBlindedBeaconBlockBellatrix) HashTreeRoot() ([32]byte, error) {
+	return hashGeneric(b)
+}
+
+func (b *SignedBeaconBlockCapella) MarshalSSZ() ([]byte, error) {
+	return marshalGeneric(b, (*eth.GenericSignedBeaconBlock).GetCapella)
+}
+
+func (b *SignedBeaconBlockCapella) HashTreeRoot() ([32]byte, error) {
+	return hashGeneric(b)
+}
+
+func (b *SignedBlindedBeaconBlockCapella) MarshalSSZ() ([]byte, error) {
+	return marshalGeneric(b, (*eth.GenericSignedBeaconBlock).GetBlindedCapella)
+}
+
+func (b *SignedBlindedBeaconBlockCapella) HashTreeRoot() ([32]byte, error) {
+	return hashGeneric(b)
+}
+
+// genericBlockRoot returns the root of the message of a generic signed beacon block,
+// which is the root the block is known by once it is published.
+func genericBlockRoot(g *eth.GenericSignedBeaconBlock) ([32]byte, error) {
+	signedBlk, err := blocks.NewSignedBeaconBlock(g.Block)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return signedBlk.Block().HashTreeRoot()
+}
+
+// FromGeneric converts a generic signed beacon block into the JSON struct of its fork.
+// The fork's version string is returned alongside the struct.
+func FromGeneric(g *eth.GenericSignedBeaconBlock) (interface{}, string, error) {
+	if g == nil {
+		return nil, "", errors.New("nil block")
+	}
+	switch b := g.Block.(type) {
+	case *eth.GenericSignedBeaconBlock_Phase0:
+		return &SignedBeaconBlock{
+			Message:   *convertInternalBeaconBlock(b.Phase0.Block),
+			Signature: hexutil.Encode(b.Phase0.Signature),
+		}, version.String(version.Phase0), nil
+	case *eth.GenericSignedBeaconBlock_Altair:
+		return &SignedBeaconBlockAltair{
+			Message:   *convertInternalBeaconBlockAltair(b.Altair.Block),
+			Signature: hexutil.Encode(b.Altair.Signature),
+		}, version.String(version.Altair), nil
+	case *eth.GenericSignedBeaconBlock_Bellatrix:
+		return &SignedBeaconBlockBellatrix{
+			Message:   *convertInternalBeaconBlockBellatrix(b.Bellatrix.Block),
+			Signature: hexutil.Encode(b.Bellatrix.Signature),
+		}, version.String(version.Bellatrix), nil
+	case *eth.GenericSignedBeaconBlock_BlindedBellatrix:
+		return &SignedBlindedBeaconBlockBellatrix{
+			Message:   *convertInternalBlindedBeaconBlockBellatrix(b.BlindedBellatrix.Block),
+			Signature: hexutil.Encode(b.BlindedBellatrix.Signature),
+		}, version.String(version.Bellatrix), nil
+	case *eth.GenericSignedBeaconBlock_Capella:
+		return &SignedBeaconBlockCapella{
+			Message:   *convertInternalBeaconBlockCapella(b.Capella.Block),
+			Signature: hexutil.Encode(b.Capella.Signature),
+		}, version.String(version.Capella), nil
+	case *eth.GenericSignedBeaconBlock_BlindedCapella:
+		return &SignedBlindedBeaconBlockCapella{
+			Message:   *convertInternalBlindedBeaconBlockCapella(b.BlindedCapella.Block),
+			Signature: hexutil.Encode(b.BlindedCapella.Signature),
+		}, version.String(version.Capella), nil
+	default:
+		return nil, "", fmt.Errorf("unsupported block type %T", g.Block)
+	}
+}
+
 func convertProposerSlashings(src []ProposerSlashing) ([]*eth.ProposerSlashing, error) {
 	if src == nil {
-		return nil, errors.New("nil b.Message.Body.ProposerSlashings")
+		return nil, fieldErrorf("b.Message.Body.ProposerSlashings", "nil %s")
 	}
 
 	proposerSlashings := make([]*eth.ProposerSlashing, len(src))
 	for i, s := range src {
-		h1Sig, err := hexutil.Decode(s.SignedHeader1.Signature)
+		h1Sig, err := decodeHex(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader1.Signature", i), s.SignedHeader1.Signature)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader1.Signature", i)
+			return nil, err
 		}
-		h1Slot, err := strconv.ParseUint(s.SignedHeader1.Message.Slot, 10, 64)
+		h1Slot, err := decodeSlot(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader1.Message.Slot", i), s.SignedHeader1.Message.Slot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader1.Message.Slot", i)
+			return nil, err
 		}
-		h1ProposerIndex, err := strconv.ParseUint(s.SignedHeader1.Message.ProposerIndex, 10, 64)
+		h1ProposerIndex, err := decodeValidatorIndex(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader1.Message.ProposerIndex", i), s.SignedHeader1.Message.ProposerIndex)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader1.Message.ProposerIndex", i)
+			return nil, err
 		}
-		h1ParentRoot, err := hexutil.Decode(s.SignedHeader1.Message.ParentRoot)
+		h1ParentRoot, err := decodeHex(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader1.Message.ParentRoot", i), s.SignedHeader1.Message.ParentRoot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader1.Message.ParentRoot", i)
+			return nil, err
 		}
-		h1StateRoot, err := hexutil.Decode(s.SignedHeader1.Message.StateRoot)
+		h1StateRoot, err := decodeHex(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader1.Message.StateRoot", i), s.SignedHeader1.Message.StateRoot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader1.Message.StateRoot", i)
+			return nil, err
 		}
-		h1BodyRoot, err := hexutil.Decode(s.SignedHeader1.Message.BodyRoot)
+		h1BodyRoot, err := decodeHex(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader1.Message.BodyRoot", i), s.SignedHeader1.Message.BodyRoot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader1.Message.BodyRoot", i)
+			return nil, err
 		}
-		h2Sig, err := hexutil.Decode(s.SignedHeader2.Signature)
+		h2Sig, err := decodeHex(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader2.Signature", i), s.SignedHeader2.Signature)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader2.Signature", i)
+			return nil, err
 		}
-		h2Slot, err := strconv.ParseUint(s.SignedHeader2.Message.Slot, 10, 64)
+		h2Slot, err := decodeSlot(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader2.Message.Slot", i), s.SignedHeader2.Message.Slot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader2.Message.Slot", i)
+			return nil, err
 		}
-		h2ProposerIndex, err := strconv.ParseUint(s.SignedHeader2.Message.ProposerIndex, 10, 64)
+		h2ProposerIndex, err := decodeValidatorIndex(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader2.Message.ProposerIndex", i), s.SignedHeader2.Message.ProposerIndex)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader2.Message.ProposerIndex", i)
+			return nil, err
 		}
-		h2ParentRoot, err := hexutil.Decode(s.SignedHeader2.Message.ParentRoot)
+		h2ParentRoot, err := decodeHex(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader2.Message.ParentRoot", i), s.SignedHeader2.Message.ParentRoot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader2.Message.ParentRoot", i)
+			return nil, err
 		}
-		h2StateRoot, err := hexutil.Decode(s.SignedHeader2.Message.StateRoot)
+		h2StateRoot, err := decodeHex(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader2.Message.StateRoot", i), s.SignedHeader2.Message.StateRoot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader2.Message.StateRoot", i)
+			return nil, err
 		}
-		h2BodyRoot, err := hexutil.Decode(s.SignedHeader2.Message.BodyRoot)
+		h2BodyRoot, err := decodeHex(fmt.Sprintf("b.Message.Body.ProposerSlashings[%d].SignedHeader2.Message.BodyRoot", i), s.SignedHeader2.Message.BodyRoot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.ProposerSlashings[%d].SignedHeader2.Message.BodyRoot", i)
+			return nil, err
 		}
 		proposerSlashings[i] = &eth.ProposerSlashing{
 			Header_1: &eth.SignedBeaconBlockHeader{
@@ -1343,90 +1567,86 @@ func convertProposerSlashings(src []ProposerSlashing) ([]*eth.ProposerSlashing,
 
 func convertAttesterSlashings(src []AttesterSlashing) ([]*eth.AttesterSlashing, error) {
 	if src == nil {
-		return nil, errors.New("nil b.Message.Body.AttesterSlashings")
+		return nil, fieldErrorf("b.Message.Body.AttesterSlashings", "nil %s")
 	}
 
 	attesterSlashings := make([]*eth.AttesterSlashing, len(src))
 	for i, s := range src {
-		a1Sig, err := hexutil.Decode(s.Attestation1.Signature)
+		a1Sig, err := decodeHex(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation1.Signature", i), s.Attestation1.Signature)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation1.Signature", i)
+			return nil, err
 		}
 		a1AttestingIndices := make([]uint64, len(s.Attestation1.AttestingIndices))
 		for j, ix := range s.Attestation1.AttestingIndices {
-			attestingIndex, err := strconv.ParseUint(ix, 10, 64)
+			attestingIndex, err := decodeUint64(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation1.AttestingIndices[%d]", i, j), ix)
 			if err != nil {
-				return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation1.AttestingIndices[%d]", i, j)
+				return nil, err
 			}
 			a1AttestingIndices[j] = attestingIndex
 		}
-		a1Slot, err := strconv.ParseUint(s.Attestation1.Data.Slot, 10, 64)
-		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation1.Data.Slot", i)
+		if err = validateAttestingIndices(a1AttestingIndices); err != nil {
+			return nil, fieldErrorf(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation1.AttestingIndices", i), "invalid %s: %v", err)
 		}
-		a1CommitteeIndex, err := strconv.ParseUint(s.Attestation1.Data.Index, 10, 64)
+		a1Slot, err := decodeSlot(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation1.Data.Slot", i), s.Attestation1.Data.Slot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation1.Data.Index", i)
+			return nil, err
 		}
-		a1BeaconBlockRoot, err := hexutil.Decode(s.Attestation1.Data.BeaconBlockRoot)
+		a1CommitteeIndex, err := decodeUint64(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation1.Data.Index", i), s.Attestation1.Data.Index)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation1.Data.BeaconBlockRoot", i)
+			return nil, err
 		}
-		a1SourceEpoch, err := strconv.ParseUint(s.Attestation1.Data.Source.Epoch, 10, 64)
+		a1BeaconBlockRoot, err := decodeHex(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation1.Data.BeaconBlockRoot", i), s.Attestation1.Data.BeaconBlockRoot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation1.Data.Source.Epoch", i)
+			return nil, err
 		}
-		a1SourceRoot, err := hexutil.Decode(s.Attestation1.Data.Source.Root)
+		a1Source, err := decodeCheckpoint(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation1.Data.Source", i), &s.Attestation1.Data.Source)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation1.Data.Source.Root", i)
+			return nil, err
 		}
-		a1TargetEpoch, err := strconv.ParseUint(s.Attestation1.Data.Target.Epoch, 10, 64)
+		a1Target, err := decodeCheckpoint(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation1.Data.Target", i), &s.Attestation1.Data.Target)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation1.Data.Target.Epoch", i)
+			return nil, err
 		}
-		a1TargetRoot, err := hexutil.Decode(s.Attestation1.Data.Target.Root)
-		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation1.Data.Target.Root", i)
+		if a1Source.Epoch > a1Target.Epoch {
+			return nil, fieldErrorf(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation1.Data.Source.Epoch", i), "invalid %s: source epoch %d is greater than target epoch %d", a1Source.Epoch, a1Target.Epoch)
 		}
-		a2Sig, err := hexutil.Decode(s.Attestation2.Signature)
+		a2Sig, err := decodeHex(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation2.Signature", i), s.Attestation2.Signature)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation2.Signature", i)
+			return nil, err
 		}
 		a2AttestingIndices := make([]uint64, len(s.Attestation2.AttestingIndices))
 		for j, ix := range s.Attestation2.AttestingIndices {
-			attestingIndex, err := strconv.ParseUint(ix, 10, 64)
+			attestingIndex, err := decodeUint64(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation2.AttestingIndices[%d]", i, j), ix)
 			if err != nil {
-				return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation2.AttestingIndices[%d]", i, j)
+				return nil, err
 			}
 			a2AttestingIndices[j] = attestingIndex
 		}
-		a2Slot, err := strconv.ParseUint(s.Attestation2.Data.Slot, 10, 64)
-		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation2.Data.Slot", i)
+		if err = validateAttestingIndices(a2AttestingIndices); err != nil {
+			return nil, fieldErrorf(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation2.AttestingIndices", i), "invalid %s: %v", err)
 		}
-		a2CommitteeIndex, err := strconv.ParseUint(s.Attestation2.Data.Index, 10, 64)
+		a2Slot, err := decodeSlot(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation2.Data.Slot", i), s.Attestation2.Data.Slot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation2.Data.Index", i)
+			return nil, err
 		}
-		a2BeaconBlockRoot, err := hexutil.Decode(s.Attestation2.Data.BeaconBlockRoot)
+		a2CommitteeIndex, err := decodeUint64(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation2.Data.Index", i), s.Attestation2.Data.Index)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation2.Data.BeaconBlockRoot", i)
+			return nil, err
 		}
-		a2SourceEpoch, err := strconv.ParseUint(s.Attestation2.Data.Source.Epoch, 10, 64)
+		a2BeaconBlockRoot, err := decodeHex(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation2.Data.BeaconBlockRoot", i), s.Attestation2.Data.BeaconBlockRoot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation2.Data.Source.Epoch", i)
+			return nil, err
 		}
-		a2SourceRoot, err := hexutil.Decode(s.Attestation2.Data.Source.Root)
+		a2Source, err := decodeCheckpoint(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation2.Data.Source", i), &s.Attestation2.Data.Source)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation2.Data.Source.Root", i)
+			return nil, err
 		}
-		a2TargetEpoch, err := strconv.ParseUint(s.Attestation2.Data.Target.Epoch, 10, 64)
+		a2Target, err := decodeCheckpoint(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation2.Data.Target", i), &s.Attestation2.Data.Target)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation2.Data.Target.Epoch", i)
+			return nil, err
 		}
-		a2TargetRoot, err := hexutil.Decode(s.Attestation2.Data.Target.Root)
-		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.AttesterSlashings[%d].Attestation2.Data.Target.Root", i)
+		if a2Source.Epoch > a2Target.Epoch {
+			return nil, fieldErrorf(fmt.Sprintf("b.Message.Body.AttesterSlashings[%d].Attestation2.Data.Source.Epoch", i), "invalid %s: source epoch %d is greater than target epoch %d", a2Source.Epoch, a2Target.Epoch)
 		}
 		attesterSlashings[i] = &eth.AttesterSlashing{
 			Attestation_1: &eth.IndexedAttestation{
@@ -1435,14 +1655,8 @@ func convertAttesterSlashings(src []AttesterSlashing) ([]*eth.AttesterSlashing,
 					Slot:            primitives.Slot(a1Slot),
 					CommitteeIndex:  primitives.CommitteeIndex(a1CommitteeIndex),
 					BeaconBlockRoot: a1BeaconBlockRoot,
-					Source: &eth.Checkpoint{
-						Epoch: primitives.Epoch(a1SourceEpoch),
-						Root:  a1SourceRoot,
-					},
-					Target: &eth.Checkpoint{
-						Epoch: primitives.Epoch(a1TargetEpoch),
-						Root:  a1TargetRoot,
-					},
+					Source:          a1Source,
+					Target:          a1Target,
 				},
 				Signature: a1Sig,
 			},
@@ -1452,14 +1666,8 @@ func convertAttesterSlashings(src []AttesterSlashing) ([]*eth.AttesterSlashing,
 					Slot:            primitives.Slot(a2Slot),
 					CommitteeIndex:  primitives.CommitteeIndex(a2CommitteeIndex),
 					BeaconBlockRoot: a2BeaconBlockRoot,
-					Source: &eth.Checkpoint{
-						Epoch: primitives.Epoch(a2SourceEpoch),
-						Root:  a2SourceRoot,
-					},
-					Target: &eth.Checkpoint{
-						Epoch: primitives.Epoch(a2TargetEpoch),
-						Root:  a2TargetRoot,
-					},
+					Source:          a2Source,
+					Target:          a2Target,
 				},
 				Signature: a2Sig,
 			},
@@ -1468,59 +1676,261 @@ func convertAttesterSlashings(src []AttesterSlashing) ([]*eth.AttesterSlashing,
 	return attesterSlashings, nil
 }
 
+// decodeTransactions decodes the transactions of an execution payload. The number of transactions is
+// checked before any of them is decoded, so that an oversized list is rejected cheaply.
+func decodeTransactions(src []string) ([][]byte, error) {
+	if len(src) > fieldparams.MaxTxsPerPayloadLength {
+		return nil, fieldErrorf("b.Message.Body.ExecutionPayload.Transactions", "invalid %s: %d transactions exceed the maximum of %d", len(src), fieldparams.MaxTxsPerPayloadLength)
+	}
+	txs := make([][]byte, len(src))
+	for i, tx := range src {
+		var err error
+		txs[i], err = decodeHex(fmt.Sprintf("b.Message.Body.ExecutionPayload.Transactions[%d]", i), tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
+
+// maxSlot is the highest slot whose start time, slot * SECONDS_PER_SLOT, fits in a uint64.
+// Larger slots can't correspond to any real block and overflow downstream arithmetic.
+func maxSlot() uint64 {
+	return math.MaxUint64 / params.BeaconConfig().SecondsPerSlot
+}
+
+// decodeUint64 decodes the unsigned integer of the given field.
+func decodeUint64(field, s string) (uint64, error) {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, wrapFieldError(err, field)
+	}
+	return v, nil
+}
+
+// decodeUint256 decodes the unsigned 256-bit integer of the given field into its little-endian SSZ encoding.
+func decodeUint256(field, s string) ([]byte, error) {
+	v, err := uint256ToHex(s)
+	if err != nil {
+		return nil, wrapFieldError(err, field)
+	}
+	return v, nil
+}
+
+// decodeSlot decodes the slot of the given field, rejecting slots above maxSlot.
+func decodeSlot(field, s string) (uint64, error) {
+	slot, err := decodeUint64(field, s)
+	if err != nil {
+		return 0, err
+	}
+	if slot > maxSlot() {
+		return 0, fieldErrorf(field, "invalid %s: slot %d exceeds the maximum of %d", slot, maxSlot())
+	}
+	return slot, nil
+}
+
+// decodeEpoch decodes the epoch of the given field, rejecting epochs whose start slot is above maxSlot.
+func decodeEpoch(field, s string) (uint64, error) {
+	epoch, err := decodeUint64(field, s)
+	if err != nil {
+		return 0, err
+	}
+	maxEpoch := maxSlot() / uint64(params.BeaconConfig().SlotsPerEpoch)
+	if epoch > maxEpoch {
+		return 0, fieldErrorf(field, "invalid %s: epoch %d exceeds the maximum of %d", epoch, maxEpoch)
+	}
+	return epoch, nil
+}
+
+// decodeValidatorIndex decodes the validator index of the given field, which must be below the validator registry limit.
+func decodeValidatorIndex(field, s string) (uint64, error) {
+	index, err := decodeUint64(field, s)
+	if err != nil {
+		return 0, err
+	}
+	if index >= fieldparams.ValidatorRegistryLimit {
+		return 0, fieldErrorf(field, "invalid %s: validator index %d exceeds the maximum of %d", index, uint64(fieldparams.ValidatorRegistryLimit-1))
+	}
+	return index, nil
+}
+
+// maxExtraDataLength is the maximum byte length of the extra data of an execution payload (MAX_EXTRA_DATA_BYTES).
+const maxExtraDataLength = 32
+
+// decodeLogsBloom decodes the logs bloom of the given field, which must be exactly fieldparams.LogsBloomLength bytes long.
+func decodeLogsBloom(field, s string) ([]byte, error) {
+	bloom, err := decodeHex(field, s)
+	if err != nil {
+		return nil, err
+	}
+	if len(bloom) != fieldparams.LogsBloomLength {
+		return nil, fieldErrorf(field, "invalid %s: logs bloom has length %d bytes, expected %d bytes", len(bloom), fieldparams.LogsBloomLength)
+	}
+	return bloom, nil
+}
+
+// decodeExtraData decodes the extra data of the given field, which must be at most maxExtraDataLength bytes long.
+func decodeExtraData(field, s string) ([]byte, error) {
+	extraData, err := decodeHex(field, s)
+	if err != nil {
+		return nil, err
+	}
+	if len(extraData) > maxExtraDataLength {
+		return nil, fieldErrorf(field, "invalid %s: extra data has length %d bytes, exceeding the maximum of %d bytes", len(extraData), maxExtraDataLength)
+	}
+	return extraData, nil
+}
+
+// decodeCheckpoint decodes the checkpoint of the given field, whose root must be 32 bytes long.
+func decodeCheckpoint(field string, c *Checkpoint) (*eth.Checkpoint, error) {
+	epoch, err := decodeEpoch(field+".Epoch", c.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	root, err := decodeHex(field+".Root", c.Root)
+	if err != nil {
+		return nil, err
+	}
+	if len(root) != fieldparams.RootLength {
+		return nil, fieldErrorf(field+".Root", "invalid %s: root has length %d bytes, expected %d bytes", len(root), fieldparams.RootLength)
+	}
+	return &eth.Checkpoint{
+		Epoch: primitives.Epoch(epoch),
+		Root:  root,
+	}, nil
+}
+
+// validateAttestingIndices checks that indices are sorted in strictly increasing order,
+// which also guarantees that they are unique.
+func validateAttestingIndices(indices []uint64) error {
+	for i := 1; i < len(indices); i++ {
+		if indices[i] == indices[i-1] {
+			return errors.Errorf("index %d at position %d is a duplicate", indices[i], i)
+		}
+		if indices[i] < indices[i-1] {
+			return errors.Errorf("index %d at position %d is lower than the previous index %d", indices[i], i, indices[i-1])
+		}
+	}
+	return nil
+}
+
+// fieldError is an error converting a field of a block. It carries the path of the field, such as
+// b.Message.Body.Attestations[0].Data.Slot, so that failures can be attributed without parsing the message.
+type fieldError struct {
+	field string
+	err   error
+}
+
+func (e *fieldError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fieldError) Unwrap() error {
+	return e.err
+}
+
+// fieldErrorf returns an error of the given field formatted according to format, whose first verb is
+// substituted with the field.
+func fieldErrorf(field, format string, args ...interface{}) error {
+	return &fieldError{field: field, err: errors.Errorf(format, append([]interface{}{field}, args...)...)}
+}
+
+// wrapFieldError annotates err as the failure to decode the given field.
+func wrapFieldError(err error, field string) error {
+	return &fieldError{field: field, err: errors.Wrapf(err, "could not decode %s", field)}
+}
+
+// decodeHex decodes the 0x-prefixed hex string of the given field. Missing prefixes and odd lengths,
+// the most common client mistakes, are reported with a dedicated error.
+func decodeHex(field, s string) ([]byte, error) {
+	if !has0xPrefix(s) {
+		return nil, fieldErrorf(field, "%s must be 0x-prefixed hex")
+	}
+	if len(s)%2 != 0 {
+		return nil, fieldErrorf(field, "%s must have an even number of hex digits")
+	}
+	b, err := hexutil.Decode(s)
+	if err != nil {
+		return nil, wrapFieldError(err, field)
+	}
+	return b, nil
+}
+
+func has0xPrefix(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
+
+// decodeSignature decodes the hex encoded BLS signature of the given field.
+func decodeSignature(field, s string) ([]byte, error) {
+	sig, err := decodeHex(field, s)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != fieldparams.BLSSignatureLength {
+		return nil, fieldErrorf(field, "invalid %s: signature must be %d bytes, got %d", fieldparams.BLSSignatureLength, len(sig))
+	}
+	return sig, nil
+}
+
+// decodeSyncCommitteeBits decodes the hex encoded bits of a sync aggregate of the given field, which must
+// hold exactly one bit for each member of the sync committee.
+func decodeSyncCommitteeBits(field, s string) ([]byte, error) {
+	bits, err := decodeHex(field, s)
+	if err != nil {
+		return nil, err
+	}
+	if len(bits) != fieldparams.SyncCommitteeLength/8 {
+		return nil, fieldErrorf(field, "invalid %s: sync committee bits have length %d bytes, expected %d bytes", len(bits), fieldparams.SyncCommitteeLength/8)
+	}
+	return bits, nil
+}
+
 func convertAtts(src []Attestation) ([]*eth.Attestation, error) {
 	if src == nil {
-		return nil, errors.New("nil b.Message.Body.Attestations")
+		return nil, fieldErrorf("b.Message.Body.Attestations", "nil %s")
 	}
 
 	atts := make([]*eth.Attestation, len(src))
 	for i, a := range src {
-		sig, err := hexutil.Decode(a.Signature)
+		aggregationBits, err := decodeHex(fmt.Sprintf("b.Message.Body.Attestations[%d].AggregationBits", i), a.AggregationBits)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Attestations[%d].Signature", i)
+			return nil, err
 		}
-		slot, err := strconv.ParseUint(a.Data.Slot, 10, 64)
+		sig, err := decodeHex(fmt.Sprintf("b.Message.Body.Attestations[%d].Signature", i), a.Signature)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Attestations[%d].Data.Slot", i)
+			return nil, err
 		}
-		committeeIndex, err := strconv.ParseUint(a.Data.Index, 10, 64)
+		slot, err := decodeSlot(fmt.Sprintf("b.Message.Body.Attestations[%d].Data.Slot", i), a.Data.Slot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Attestations[%d].Data.Index", i)
+			return nil, err
 		}
-		beaconBlockRoot, err := hexutil.Decode(a.Data.BeaconBlockRoot)
+		committeeIndex, err := decodeUint64(fmt.Sprintf("b.Message.Body.Attestations[%d].Data.Index", i), a.Data.Index)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Attestations[%d].Data.BeaconBlockRoot", i)
+			return nil, err
 		}
-		sourceEpoch, err := strconv.ParseUint(a.Data.Source.Epoch, 10, 64)
+		beaconBlockRoot, err := decodeHex(fmt.Sprintf("b.Message.Body.Attestations[%d].Data.BeaconBlockRoot", i), a.Data.BeaconBlockRoot)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Attestations[%d].Data.Source.Epoch", i)
+			return nil, err
 		}
-		sourceRoot, err := hexutil.Decode(a.Data.Source.Root)
+		source, err := decodeCheckpoint(fmt.Sprintf("b.Message.Body.Attestations[%d].Data.Source", i), &a.Data.Source)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Attestations[%d].Data.Source.Root", i)
+			return nil, err
 		}
-		targetEpoch, err := strconv.ParseUint(a.Data.Target.Epoch, 10, 64)
+		target, err := decodeCheckpoint(fmt.Sprintf("b.Message.Body.Attestations[%d].Data.Target", i), &a.Data.Target)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Attestations[%d].Data.Target.Epoch", i)
+			return nil, err
 		}
-		targetRoot, err := hexutil.Decode(a.Data.Target.Root)
-		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Attestations[%d].Data.Target.Root", i)
+		if source.Epoch > target.Epoch {
+			return nil, fieldErrorf(fmt.Sprintf("b.Message.Body.Attestations[%d].Data.Source.Epoch", i), "invalid %s: source epoch %d is greater than target epoch %d", source.Epoch, target.Epoch)
 		}
 		atts[i] = &eth.Attestation{
-			AggregationBits: []byte(a.AggregationBits),
+			AggregationBits: aggregationBits,
 			Data: &eth.AttestationData{
 				Slot:            primitives.Slot(slot),
 				CommitteeIndex:  primitives.CommitteeIndex(committeeIndex),
 				BeaconBlockRoot: beaconBlockRoot,
-				Source: &eth.Checkpoint{
-					Epoch: primitives.Epoch(sourceEpoch),
-					Root:  sourceRoot,
-				},
-				Target: &eth.Checkpoint{
-					Epoch: primitives.Epoch(targetEpoch),
-					Root:  targetRoot,
-				},
+				Source:          source,
+				Target:          target,
 			},
 			Signature: sig,
 		}
@@ -1530,34 +1940,38 @@ func convertAtts(src []Attestation) ([]*eth.Attestation, error) {
 
 func convertDeposits(src []Deposit) ([]*eth.Deposit, error) {
 	if src == nil {
-		return nil, errors.New("nil b.Message.Body.Deposits")
+		return nil, fieldErrorf("b.Message.Body.Deposits", "nil %s")
 	}
 
+	proofLen := params.BeaconConfig().DepositContractTreeDepth + 1
 	deposits := make([]*eth.Deposit, len(src))
 	for i, d := range src {
+		if uint64(len(d.Proof)) != proofLen {
+			return nil, fieldErrorf(fmt.Sprintf("b.Message.Body.Deposits[%d].Proof", i), "invalid %s: proof has %d elements, expected %d", len(d.Proof), proofLen)
+		}
 		proof := make([][]byte, len(d.Proof))
 		for j, p := range d.Proof {
 			var err error
-			proof[j], err = hexutil.Decode(p)
+			proof[j], err = decodeHex(fmt.Sprintf("b.Message.Body.Deposits[%d].Proof[%d]", i, j), p)
 			if err != nil {
-				return nil, errors.Wrapf(err, "could not decode b.Message.Body.Deposits[%d].Proof[%d]", i, j)
+				return nil, err
 			}
 		}
-		pubkey, err := hexutil.Decode(d.Data.Pubkey)
+		pubkey, err := decodeHex(fmt.Sprintf("b.Message.Body.Deposits[%d].Pubkey", i), d.Data.Pubkey)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Deposits[%d].Pubkey", i)
+			return nil, err
 		}
-		withdrawalCreds, err := hexutil.Decode(d.Data.WithdrawalCredentials)
+		withdrawalCreds, err := decodeHex(fmt.Sprintf("b.Message.Body.Deposits[%d].WithdrawalCredentials", i), d.Data.WithdrawalCredentials)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Deposits[%d].WithdrawalCredentials", i)
+			return nil, err
 		}
-		amount, err := strconv.ParseUint(d.Data.Amount, 10, 64)
+		amount, err := decodeUint64(fmt.Sprintf("b.Message.Body.Deposits[%d].Amount", i), d.Data.Amount)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Deposits[%d].Amount", i)
+			return nil, err
 		}
-		sig, err := hexutil.Decode(d.Data.Signature)
+		sig, err := decodeHex(fmt.Sprintf("b.Message.Body.Deposits[%d].Signature", i), d.Data.Signature)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.Deposits[%d].Signature", i)
+			return nil, err
 		}
 		deposits[i] = &eth.Deposit{
 			Proof: proof,
@@ -1574,23 +1988,29 @@ func convertDeposits(src []Deposit) ([]*eth.Deposit, error) {
 
 func convertExits(src []SignedVoluntaryExit) ([]*eth.SignedVoluntaryExit, error) {
 	if src == nil {
-		return nil, errors.New("nil b.Message.Body.VoluntaryExits")
+		return nil, fieldErrorf("b.Message.Body.VoluntaryExits", "nil %s")
 	}
 
 	exits := make([]*eth.SignedVoluntaryExit, len(src))
+	// A validator can only exit once, so a block containing two exits for the same validator is invalid.
+	seen := make(map[uint64]int, len(src))
 	for i, e := range src {
-		sig, err := hexutil.Decode(e.Signature)
+		sig, err := decodeHex(fmt.Sprintf("b.Message.Body.VoluntaryExits[%d].Signature", i), e.Signature)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.VoluntaryExits[%d].Signature", i)
+			return nil, err
 		}
-		epoch, err := strconv.ParseUint(e.Message.Epoch, 10, 64)
+		epoch, err := decodeEpoch(fmt.Sprintf("b.Message.Body.VoluntaryExits[%d].Epoch", i), e.Message.Epoch)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.VoluntaryExits[%d].Epoch", i)
+			return nil, err
 		}
-		validatorIndex, err := strconv.ParseUint(e.Message.ValidatorIndex, 10, 64)
+		validatorIndex, err := decodeValidatorIndex(fmt.Sprintf("b.Message.Body.VoluntaryExits[%d].ValidatorIndex", i), e.Message.ValidatorIndex)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.VoluntaryExits[%d].ValidatorIndex", i)
+			return nil, err
+		}
+		if j, ok := seen[validatorIndex]; ok {
+			return nil, fieldErrorf(fmt.Sprintf("b.Message.Body.VoluntaryExits[%d]", i), "%s is a duplicate of b.Message.Body.VoluntaryExits[%d] for validator index %d", j, validatorIndex)
 		}
+		seen[validatorIndex] = i
 		exits[i] = &eth.SignedVoluntaryExit{
 			Exit: &eth.VoluntaryExit{
 				Epoch:          primitives.Epoch(epoch),
@@ -1604,26 +2024,37 @@ func convertExits(src []SignedVoluntaryExit) ([]*eth.SignedVoluntaryExit, error)
 
 func convertBlsChanges(src []SignedBlsToExecutionChange) ([]*eth.SignedBLSToExecutionChange, error) {
 	if src == nil {
-		return nil, errors.New("nil b.Message.Body.BlsToExecutionChanges")
+		return nil, fieldErrorf("b.Message.Body.BlsToExecutionChanges", "nil %s")
 	}
 
 	changes := make([]*eth.SignedBLSToExecutionChange, len(src))
+	seen := make(map[uint64]int, len(src))
 	for i, ch := range src {
-		sig, err := hexutil.Decode(ch.Signature)
+		sig, err := decodeHex(fmt.Sprintf("b.Message.Body.BlsToExecutionChanges[%d].Signature", i), ch.Signature)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.BlsToExecutionChanges[%d].Signature", i)
+			return nil, err
 		}
-		index, err := strconv.ParseUint(ch.Message.ValidatorIndex, 10, 64)
+		index, err := decodeValidatorIndex(fmt.Sprintf("b.Message.Body.BlsToExecutionChanges[%d].Message.ValidatorIndex", i), ch.Message.ValidatorIndex)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.BlsToExecutionChanges[%d].Message.ValidatorIndex", i)
+			return nil, err
 		}
-		pubkey, err := hexutil.Decode(ch.Message.FromBlsPubkey)
+		if j, ok := seen[index]; ok {
+			return nil, fieldErrorf(fmt.Sprintf("b.Message.Body.BlsToExecutionChanges[%d]", i), "%s is a duplicate of b.Message.Body.BlsToExecutionChanges[%d] for validator index %d", j, index)
+		}
+		seen[index] = i
+		pubkey, err := decodeHex(fmt.Sprintf("b.Message.Body.BlsToExecutionChanges[%d].Message.FromBlsPubkey", i), ch.Message.FromBlsPubkey)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.BlsToExecutionChanges[%d].Message.FromBlsPubkey", i)
+			return nil, err
+		}
+		if len(pubkey) != fieldparams.BLSPubkeyLength {
+			return nil, fieldErrorf(fmt.Sprintf("b.Message.Body.BlsToExecutionChanges[%d].Message.FromBlsPubkey", i), "invalid %s: pubkey has length %d bytes, expected %d bytes", len(pubkey), fieldparams.BLSPubkeyLength)
 		}
-		address, err := hexutil.Decode(ch.Message.ToExecutionAddress)
+		address, err := decodeHex(fmt.Sprintf("b.Message.Body.BlsToExecutionChanges[%d].Message.ToExecutionAddress", i), ch.Message.ToExecutionAddress)
 		if err != nil {
-			return nil, errors.Wrapf(err, "could not decode b.Message.Body.BlsToExecutionChanges[%d].Message.ToExecutionAddress", i)
+			return nil, err
+		}
+		if len(address) != fieldparams.FeeRecipientLength {
+			return nil, fieldErrorf(fmt.Sprintf("b.Message.Body.BlsToExecutionChanges[%d].Message.ToExecutionAddress", i), "invalid %s: address has length %d bytes, expected %d bytes", len(address), fieldparams.FeeRecipientLength)
 		}
 		changes[i] = &eth.SignedBLSToExecutionChange{
 			Message: &eth.BLSToExecutionChange{
@@ -1637,6 +2068,382 @@ func convertBlsChanges(src []SignedBlsToExecutionChange) ([]*eth.SignedBLSToExec
 	return changes, nil
 }
 
+func convertInternalBeaconBlock(b *eth.BeaconBlock) *BeaconBlock {
+	return &BeaconBlock{
+		Slot:          fmt.Sprintf("%d", b.Slot),
+		ProposerIndex: fmt.Sprintf("%d", b.ProposerIndex),
+		ParentRoot:    hexutil.Encode(b.ParentRoot),
+		StateRoot:     hexutil.Encode(b.StateRoot),
+		Body: BeaconBlockBody{
+			RandaoReveal:      hexutil.Encode(b.Body.RandaoReveal),
+			Eth1Data:          convertInternalEth1Data(b.Body.Eth1Data),
+			Graffiti:          hexutil.Encode(b.Body.Graffiti),
+			ProposerSlashings: convertInternalProposerSlashings(b.Body.ProposerSlashings),
+			AttesterSlashings: convertInternalAttesterSlashings(b.Body.AttesterSlashings),
+			Attestations:      convertInternalAtts(b.Body.Attestations),
+			Deposits:          convertInternalDeposits(b.Body.Deposits),
+			VoluntaryExits:    convertInternalExits(b.Body.VoluntaryExits),
+		},
+	}
+}
+
+func convertInternalBeaconBlockAltair(b *eth.BeaconBlockAltair) *BeaconBlockAltair {
+	return &BeaconBlockAltair{
+		Slot:          fmt.Sprintf("%d", b.Slot),
+		ProposerIndex: fmt.Sprintf("%d", b.ProposerIndex),
+		ParentRoot:    hexutil.Encode(b.ParentRoot),
+		StateRoot:     hexutil.Encode(b.StateRoot),
+		Body: BeaconBlockBodyAltair{
+			RandaoReveal:      hexutil.Encode(b.Body.RandaoReveal),
+			Eth1Data:          convertInternalEth1Data(b.Body.Eth1Data),
+			Graffiti:          hexutil.Encode(b.Body.Graffiti),
+			ProposerSlashings: convertInternalProposerSlashings(b.Body.ProposerSlashings),
+			AttesterSlashings: convertInternalAttesterSlashings(b.Body.AttesterSlashings),
+			Attestations:      convertInternalAtts(b.Body.Attestations),
+			Deposits:          convertInternalDeposits(b.Body.Deposits),
+			VoluntaryExits:    convertInternalExits(b.Body.VoluntaryExits),
+			SyncAggregate:     convertInternalSyncAggregate(b.Body.SyncAggregate),
+		},
+	}
+}
+
+func convertInternalBeaconBlockBellatrix(b *eth.BeaconBlockBellatrix) *BeaconBlockBellatrix {
+	return &BeaconBlockBellatrix{
+		Slot:          fmt.Sprintf("%d", b.Slot),
+		ProposerIndex: fmt.Sprintf("%d", b.ProposerIndex),
+		ParentRoot:    hexutil.Encode(b.ParentRoot),
+		StateRoot:     hexutil.Encode(b.StateRoot),
+		Body: BeaconBlockBodyBellatrix{
+			RandaoReveal:      hexutil.Encode(b.Body.RandaoReveal),
+			Eth1Data:          convertInternalEth1Data(b.Body.Eth1Data),
+			Graffiti:          hexutil.Encode(b.Body.Graffiti),
+			ProposerSlashings: convertInternalProposerSlashings(b.Body.ProposerSlashings),
+			AttesterSlashings: convertInternalAttesterSlashings(b.Body.AttesterSlashings),
+			Attestations:      convertInternalAtts(b.Body.Attestations),
+			Deposits:          convertInternalDeposits(b.Body.Deposits),
+			VoluntaryExits:    convertInternalExits(b.Body.VoluntaryExits),
+			SyncAggregate:     convertInternalSyncAggregate(b.Body.SyncAggregate),
+			ExecutionPayload:  convertInternalPayload(b.Body.ExecutionPayload),
+		},
+	}
+}
+
+func convertInternalBlindedBeaconBlockBellatrix(b *eth.BlindedBeaconBlockBellatrix) *BlindedBeaconBlockBellatrix {
+	return &BlindedBeaconBlockBellatrix{
+		Slot:          fmt.Sprintf("%d", b.Slot),
+		ProposerIndex: fmt.Sprintf("%d", b.ProposerIndex),
+		ParentRoot:    hexutil.Encode(b.ParentRoot),
+		StateRoot:     hexutil.Encode(b.StateRoot),
+		Body: BlindedBeaconBlockBodyBellatrix{
+			RandaoReveal:           hexutil.Encode(b.Body.RandaoReveal),
+			Eth1Data:               convertInternalEth1Data(b.Body.Eth1Data),
+			Graffiti:               hexutil.Encode(b.Body.Graffiti),
+			ProposerSlashings:      convertInternalProposerSlashings(b.Body.ProposerSlashings),
+			AttesterSlashings:      convertInternalAttesterSlashings(b.Body.AttesterSlashings),
+			Attestations:           convertInternalAtts(b.Body.Attestations),
+			Deposits:               convertInternalDeposits(b.Body.Deposits),
+			VoluntaryExits:         convertInternalExits(b.Body.VoluntaryExits),
+			SyncAggregate:          convertInternalSyncAggregate(b.Body.SyncAggregate),
+			ExecutionPayloadHeader: convertInternalPayloadHeader(b.Body.ExecutionPayloadHeader),
+		},
+	}
+}
+
+func convertInternalBeaconBlockCapella(b *eth.BeaconBlockCapella) *BeaconBlockCapella {
+	return &BeaconBlockCapella{
+		Slot:          fmt.Sprintf("%d", b.Slot),
+		ProposerIndex: fmt.Sprintf("%d", b.ProposerIndex),
+		ParentRoot:    hexutil.Encode(b.ParentRoot),
+		StateRoot:     hexutil.Encode(b.StateRoot),
+		Body: BeaconBlockBodyCapella{
+			RandaoReveal:          hexutil.Encode(b.Body.RandaoReveal),
+			Eth1Data:              convertInternalEth1Data(b.Body.Eth1Data),
+			Graffiti:              hexutil.Encode(b.Body.Graffiti),
+			ProposerSlashings:     convertInternalProposerSlashings(b.Body.ProposerSlashings),
+			AttesterSlashings:     convertInternalAttesterSlashings(b.Body.AttesterSlashings),
+			Attestations:          convertInternalAtts(b.Body.Attestations),
+			Deposits:              convertInternalDeposits(b.Body.Deposits),
+			VoluntaryExits:        convertInternalExits(b.Body.VoluntaryExits),
+			SyncAggregate:         convertInternalSyncAggregate(b.Body.SyncAggregate),
+			ExecutionPayload:      convertInternalPayloadCapella(b.Body.ExecutionPayload),
+			BlsToExecutionChanges: convertInternalBlsChanges(b.Body.BlsToExecutionChanges),
+		},
+	}
+}
+
+func convertInternalBlindedBeaconBlockCapella(b *eth.BlindedBeaconBlockCapella) *BlindedBeaconBlockCapella {
+	return &BlindedBeaconBlockCapella{
+		Slot:          fmt.Sprintf("%d", b.Slot),
+		ProposerIndex: fmt.Sprintf("%d", b.ProposerIndex),
+		ParentRoot:    hexutil.Encode(b.ParentRoot),
+		StateRoot:     hexutil.Encode(b.StateRoot),
+		Body: BlindedBeaconBlockBodyCapella{
+			RandaoReveal:           hexutil.Encode(b.Body.RandaoReveal),
+			Eth1Data:               convertInternalEth1Data(b.Body.Eth1Data),
+			Graffiti:               hexutil.Encode(b.Body.Graffiti),
+			ProposerSlashings:      convertInternalProposerSlashings(b.Body.ProposerSlashings),
+			AttesterSlashings:      convertInternalAttesterSlashings(b.Body.AttesterSlashings),
+			Attestations:           convertInternalAtts(b.Body.Attestations),
+			Deposits:               convertInternalDeposits(b.Body.Deposits),
+			VoluntaryExits:         convertInternalExits(b.Body.VoluntaryExits),
+			SyncAggregate:          convertInternalSyncAggregate(b.Body.SyncAggregate),
+			ExecutionPayloadHeader: convertInternalPayloadHeaderCapella(b.Body.ExecutionPayloadHeader),
+			BlsToExecutionChanges:  convertInternalBlsChanges(b.Body.BlsToExecutionChanges),
+		},
+	}
+}
+
+// convertInternalExecutionData converts the execution payload of a block of any fork, or its header when the
+// block is blinded.
+func convertInternalExecutionData(payload interfaces.ExecutionData) (interface{}, error) {
+	switch p := payload.Proto().(type) {
+	case *enginev1.ExecutionPayload:
+		return convertInternalPayload(p), nil
+	case *enginev1.ExecutionPayloadHeader:
+		return convertInternalPayloadHeader(p), nil
+	case *enginev1.ExecutionPayloadCapella:
+		return convertInternalPayloadCapella(p), nil
+	case *enginev1.ExecutionPayloadHeaderCapella:
+		return convertInternalPayloadHeaderCapella(p), nil
+	default:
+		return nil, fmt.Errorf("unsupported execution payload type %T", p)
+	}
+}
+
+func convertInternalPayload(payload *enginev1.ExecutionPayload) ExecutionPayload {
+	txs := make([]string, len(payload.Transactions))
+	for i, tx := range payload.Transactions {
+		txs[i] = hexutil.Encode(tx)
+	}
+	return ExecutionPayload{
+		ParentHash:    hexutil.Encode(payload.ParentHash),
+		FeeRecipient:  hexutil.Encode(payload.FeeRecipient),
+		StateRoot:     hexutil.Encode(payload.StateRoot),
+		ReceiptsRoot:  hexutil.Encode(payload.ReceiptsRoot),
+		LogsBloom:     hexutil.Encode(payload.LogsBloom),
+		PrevRandao:    hexutil.Encode(payload.PrevRandao),
+		BlockNumber:   fmt.Sprintf("%d", payload.BlockNumber),
+		GasLimit:      fmt.Sprintf("%d", payload.GasLimit),
+		GasUsed:       fmt.Sprintf("%d", payload.GasUsed),
+		Timestamp:     fmt.Sprintf("%d", payload.Timestamp),
+		ExtraData:     hexutil.Encode(payload.ExtraData),
+		BaseFeePerGas: hexToUint256Decimal(payload.BaseFeePerGas),
+		BlockHash:     hexutil.Encode(payload.BlockHash),
+		Transactions:  txs,
+	}
+}
+
+func convertInternalPayloadCapella(payload *enginev1.ExecutionPayloadCapella) ExecutionPayloadCapella {
+	txs := make([]string, len(payload.Transactions))
+	for i, tx := range payload.Transactions {
+		txs[i] = hexutil.Encode(tx)
+	}
+	withdrawals := make([]Withdrawal, len(payload.Withdrawals))
+	for i, w := range payload.Withdrawals {
+		withdrawals[i] = Withdrawal{
+			WithdrawalIndex:  fmt.Sprintf("%d", w.Index),
+			ValidatorIndex:   fmt.Sprintf("%d", w.ValidatorIndex),
+			ExecutionAddress: hexutil.Encode(w.Address),
+			Amount:           fmt.Sprintf("%d", w.Amount),
+		}
+	}
+	return ExecutionPayloadCapella{
+		ParentHash:    hexutil.Encode(payload.ParentHash),
+		FeeRecipient:  hexutil.Encode(payload.FeeRecipient),
+		StateRoot:     hexutil.Encode(payload.StateRoot),
+		ReceiptsRoot:  hexutil.Encode(payload.ReceiptsRoot),
+		LogsBloom:     hexutil.Encode(payload.LogsBloom),
+		PrevRandao:    hexutil.Encode(payload.PrevRandao),
+		BlockNumber:   fmt.Sprintf("%d", payload.BlockNumber),
+		GasLimit:      fmt.Sprintf("%d", payload.GasLimit),
+		GasUsed:       fmt.Sprintf("%d", payload.GasUsed),
+		Timestamp:     fmt.Sprintf("%d", payload.Timestamp),
+		ExtraData:     hexutil.Encode(payload.ExtraData),
+		BaseFeePerGas: hexToUint256Decimal(payload.BaseFeePerGas),
+		BlockHash:     hexutil.Encode(payload.BlockHash),
+		Transactions:  txs,
+		Withdrawals:   withdrawals,
+	}
+}
+
+func convertInternalPayloadHeader(h *enginev1.ExecutionPayloadHeader) ExecutionPayloadHeader {
+	return ExecutionPayloadHeader{
+		ParentHash:       hexutil.Encode(h.ParentHash),
+		FeeRecipient:     hexutil.Encode(h.FeeRecipient),
+		StateRoot:        hexutil.Encode(h.StateRoot),
+		ReceiptsRoot:     hexutil.Encode(h.ReceiptsRoot),
+		LogsBloom:        hexutil.Encode(h.LogsBloom),
+		PrevRandao:       hexutil.Encode(h.PrevRandao),
+		BlockNumber:      fmt.Sprintf("%d", h.BlockNumber),
+		GasLimit:         fmt.Sprintf("%d", h.GasLimit),
+		GasUsed:          fmt.Sprintf("%d", h.GasUsed),
+		Timestamp:        fmt.Sprintf("%d", h.Timestamp),
+		ExtraData:        hexutil.Encode(h.ExtraData),
+		BaseFeePerGas:    hexToUint256Decimal(h.BaseFeePerGas),
+		BlockHash:        hexutil.Encode(h.BlockHash),
+		TransactionsRoot: hexutil.Encode(h.TransactionsRoot),
+	}
+}
+
+func convertInternalPayloadHeaderCapella(h *enginev1.ExecutionPayloadHeaderCapella) ExecutionPayloadHeaderCapella {
+	return ExecutionPayloadHeaderCapella{
+		ParentHash:       hexutil.Encode(h.ParentHash),
+		FeeRecipient:     hexutil.Encode(h.FeeRecipient),
+		StateRoot:        hexutil.Encode(h.StateRoot),
+		ReceiptsRoot:     hexutil.Encode(h.ReceiptsRoot),
+		LogsBloom:        hexutil.Encode(h.LogsBloom),
+		PrevRandao:       hexutil.Encode(h.PrevRandao),
+		BlockNumber:      fmt.Sprintf("%d", h.BlockNumber),
+		GasLimit:         fmt.Sprintf("%d", h.GasLimit),
+		GasUsed:          fmt.Sprintf("%d", h.GasUsed),
+		Timestamp:        fmt.Sprintf("%d", h.Timestamp),
+		ExtraData:        hexutil.Encode(h.ExtraData),
+		BaseFeePerGas:    hexToUint256Decimal(h.BaseFeePerGas),
+		BlockHash:        hexutil.Encode(h.BlockHash),
+		TransactionsRoot: hexutil.Encode(h.TransactionsRoot),
+		WithdrawalsRoot:  hexutil.Encode(h.WithdrawalsRoot),
+	}
+}
+
+func convertInternalEth1Data(src *eth.Eth1Data) Eth1Data {
+	return Eth1Data{
+		DepositRoot:  hexutil.Encode(src.DepositRoot),
+		DepositCount: fmt.Sprintf("%d", src.DepositCount),
+		BlockHash:    hexutil.Encode(src.BlockHash),
+	}
+}
+
+func convertInternalSyncAggregate(src *eth.SyncAggregate) SyncAggregate {
+	return SyncAggregate{
+		SyncCommitteeBits:      hexutil.Encode(src.SyncCommitteeBits),
+		SyncCommitteeSignature: hexutil.Encode(src.SyncCommitteeSignature),
+	}
+}
+
+func convertInternalProposerSlashings(src []*eth.ProposerSlashing) []ProposerSlashing {
+	proposerSlashings := make([]ProposerSlashing, len(src))
+	for i, s := range src {
+		proposerSlashings[i] = ProposerSlashing{
+			SignedHeader1: convertInternalSignedHeader(s.Header_1),
+			SignedHeader2: convertInternalSignedHeader(s.Header_2),
+		}
+	}
+	return proposerSlashings
+}
+
+func convertInternalSignedHeader(src *eth.SignedBeaconBlockHeader) SignedBeaconBlockHeader {
+	return SignedBeaconBlockHeader{
+		Message: BeaconBlockHeader{
+			Slot:          fmt.Sprintf("%d", src.Header.Slot),
+			ProposerIndex: fmt.Sprintf("%d", src.Header.ProposerIndex),
+			ParentRoot:    hexutil.Encode(src.Header.ParentRoot),
+			StateRoot:     hexutil.Encode(src.Header.StateRoot),
+			BodyRoot:      hexutil.Encode(src.Header.BodyRoot),
+		},
+		Signature: hexutil.Encode(src.Signature),
+	}
+}
+
+func convertInternalAttesterSlashings(src []*eth.AttesterSlashing) []AttesterSlashing {
+	attesterSlashings := make([]AttesterSlashing, len(src))
+	for i, s := range src {
+		attesterSlashings[i] = AttesterSlashing{
+			Attestation1: convertInternalIndexedAtt(s.Attestation_1),
+			Attestation2: convertInternalIndexedAtt(s.Attestation_2),
+		}
+	}
+	return attesterSlashings
+}
+
+func convertInternalIndexedAtt(src *eth.IndexedAttestation) IndexedAttestation {
+	indices := make([]string, len(src.AttestingIndices))
+	for i, index := range src.AttestingIndices {
+		indices[i] = fmt.Sprintf("%d", index)
+	}
+	return IndexedAttestation{
+		AttestingIndices: indices,
+		Data:             convertInternalAttData(src.Data),
+		Signature:        hexutil.Encode(src.Signature),
+	}
+}
+
+func convertInternalAtts(src []*eth.Attestation) []Attestation {
+	atts := make([]Attestation, len(src))
+	for i, a := range src {
+		atts[i] = Attestation{
+			AggregationBits: hexutil.Encode(a.AggregationBits),
+			Data:            convertInternalAttData(a.Data),
+			Signature:       hexutil.Encode(a.Signature),
+		}
+	}
+	return atts
+}
+
+func convertInternalAttData(src *eth.AttestationData) AttestationData {
+	return AttestationData{
+		Slot:            fmt.Sprintf("%d", src.Slot),
+		Index:           fmt.Sprintf("%d", src.CommitteeIndex),
+		BeaconBlockRoot: hexutil.Encode(src.BeaconBlockRoot),
+		Source: Checkpoint{
+			Epoch: fmt.Sprintf("%d", src.Source.Epoch),
+			Root:  hexutil.Encode(src.Source.Root),
+		},
+		Target: Checkpoint{
+			Epoch: fmt.Sprintf("%d", src.Target.Epoch),
+			Root:  hexutil.Encode(src.Target.Root),
+		},
+	}
+}
+
+func convertInternalDeposits(src []*eth.Deposit) []Deposit {
+	deposits := make([]Deposit, len(src))
+	for i, d := range src {
+		proof := make([]string, len(d.Proof))
+		for j, p := range d.Proof {
+			proof[j] = hexutil.Encode(p)
+		}
+		deposits[i] = Deposit{
+			Proof: proof,
+			Data: DepositData{
+				Pubkey:                hexutil.Encode(d.Data.PublicKey),
+				WithdrawalCredentials: hexutil.Encode(d.Data.WithdrawalCredentials),
+				Amount:                fmt.Sprintf("%d", d.Data.Amount),
+				Signature:             hexutil.Encode(d.Data.Signature),
+			},
+		}
+	}
+	return deposits
+}
+
+func convertInternalExits(src []*eth.SignedVoluntaryExit) []SignedVoluntaryExit {
+	exits := make([]SignedVoluntaryExit, len(src))
+	for i, e := range src {
+		exits[i] = SignedVoluntaryExit{
+			Message: VoluntaryExit{
+				Epoch:          fmt.Sprintf("%d", e.Exit.Epoch),
+				ValidatorIndex: fmt.Sprintf("%d", e.Exit.ValidatorIndex),
+			},
+			Signature: hexutil.Encode(e.Signature),
+		}
+	}
+	return exits
+}
+
+func convertInternalBlsChanges(src []*eth.SignedBLSToExecutionChange) []SignedBlsToExecutionChange {
+	changes := make([]SignedBlsToExecutionChange, len(src))
+	for i, ch := range src {
+		changes[i] = SignedBlsToExecutionChange{
+			Message: BlsToExecutionChange{
+				ValidatorIndex:     fmt.Sprintf("%d", ch.Message.ValidatorIndex),
+				FromBlsPubkey:      hexutil.Encode(ch.Message.FromBlsPubkey),
+				ToExecutionAddress: hexutil.Encode(ch.Message.ToExecutionAddress),
+			},
+			Signature: hexutil.Encode(ch.Signature),
+		}
+	}
+	return changes
+}
+
 func uint256ToHex(num string) ([]byte, error) {
 	uint256, ok := new(big.Int).SetString(num, 10)
 	if !ok {
@@ -1646,5 +2453,11 @@ func uint256ToHex(num string) ([]byte, error) {
 	if len(bigEndian) > 32 {
 		return nil, errors.New("number too big for Uint256")
 	}
-	return bytesutil2.ReverseByteOrder(bytesutil2.PadTo(bigEndian, 32)), nil
+	return bytesutil2.PadTo(bytesutil2.ReverseByteOrder(bigEndian), 32), nil
+}
+
+// hexToUint256Decimal is the inverse of uint256ToHex. It converts a little-endian Uint256
+// into the decimal string representation used by the JSON API.
+func hexToUint256Decimal(num []byte) string {
+	return new(big.Int).SetBytes(bytesutil2.ReverseByteOrder(num)).String()
 }
@@ -0,0 +1,74 @@
+//go:build go1.18
+
+package beacon
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func FuzzSignedBeaconBlock_ToGeneric(f *testing.F) {
+	f.Add([]byte(phase0Block))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		b := &SignedBeaconBlock{}
+		if err := json.Unmarshal(data, b); err != nil {
+			return
+		}
+		_, _ = b.ToGeneric()
+	})
+}
+
+func FuzzSignedBeaconBlockAltair_ToGeneric(f *testing.F) {
+	f.Add([]byte(altairBlock))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		b := &SignedBeaconBlockAltair{}
+		if err := json.Unmarshal(data, b); err != nil {
+			return
+		}
+		_, _ = b.ToGeneric()
+	})
+}
+
+func FuzzSignedBeaconBlockBellatrix_ToGeneric(f *testing.F) {
+	f.Add([]byte(bellatrixBlock))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		b := &SignedBeaconBlockBellatrix{}
+		if err := json.Unmarshal(data, b); err != nil {
+			return
+		}
+		_, _ = b.ToGeneric()
+	})
+}
+
+func FuzzSignedBlindedBeaconBlockBellatrix_ToGeneric(f *testing.F) {
+	f.Add([]byte(blindedBellatrixBlock))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		b := &SignedBlindedBeaconBlockBellatrix{}
+		if err := json.Unmarshal(data, b); err != nil {
+			return
+		}
+		_, _ = b.ToGeneric()
+	})
+}
+
+func FuzzSignedBeaconBlockCapella_ToGeneric(f *testing.F) {
+	f.Add([]byte(capellaBlock))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		b := &SignedBeaconBlockCapella{}
+		if err := json.Unmarshal(data, b); err != nil {
+			return
+		}
+		_, _ = b.ToGeneric()
+	})
+}
+
+func FuzzSignedBlindedBeaconBlockCapella_ToGeneric(f *testing.F) {
+	f.Add([]byte(blindedCapellaBlock))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		b := &SignedBlindedBeaconBlockCapella{}
+		if err := json.Unmarshal(data, b); err != nil {
+			return
+		}
+		_, _ = b.ToGeneric()
+	})
+}
@@ -0,0 +1,869 @@
+package beacon
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestMarshalSSZ(t *testing.T) {
+	t.Run("Phase 0", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		expected, err := b.ToGeneric()
+		require.NoError(t, err)
+		sszBytes, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		decoded := &eth.SignedBeaconBlock{}
+		require.NoError(t, decoded.UnmarshalSSZ(sszBytes))
+		assert.DeepSSZEqual(t, expected.GetPhase0(), decoded)
+	})
+	t.Run("Altair", func(t *testing.T) {
+		var b *SignedBeaconBlockAltair
+		require.NoError(t, json.Unmarshal([]byte(altairBlock), &b))
+		expected, err := b.ToGeneric()
+		require.NoError(t, err)
+		sszBytes, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		decoded := &eth.SignedBeaconBlockAltair{}
+		require.NoError(t, decoded.UnmarshalSSZ(sszBytes))
+		assert.DeepSSZEqual(t, expected.GetAltair(), decoded)
+	})
+	t.Run("Bellatrix", func(t *testing.T) {
+		var b *SignedBeaconBlockBellatrix
+		require.NoError(t, json.Unmarshal([]byte(bellatrixBlock), &b))
+		expected, err := b.ToGeneric()
+		require.NoError(t, err)
+		sszBytes, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		decoded := &eth.SignedBeaconBlockBellatrix{}
+		require.NoError(t, decoded.UnmarshalSSZ(sszBytes))
+		assert.DeepSSZEqual(t, expected.GetBellatrix(), decoded)
+	})
+	t.Run("Blinded Bellatrix", func(t *testing.T) {
+		var b *SignedBlindedBeaconBlockBellatrix
+		require.NoError(t, json.Unmarshal([]byte(blindedBellatrixBlock), &b))
+		expected, err := b.ToGeneric()
+		require.NoError(t, err)
+		sszBytes, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		decoded := &eth.SignedBlindedBeaconBlockBellatrix{}
+		require.NoError(t, decoded.UnmarshalSSZ(sszBytes))
+		assert.DeepSSZEqual(t, expected.GetBlindedBellatrix(), decoded)
+	})
+	t.Run("Capella", func(t *testing.T) {
+		var b *SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+		expected, err := b.ToGeneric()
+		require.NoError(t, err)
+		sszBytes, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		decoded := &eth.SignedBeaconBlockCapella{}
+		require.NoError(t, decoded.UnmarshalSSZ(sszBytes))
+		assert.DeepSSZEqual(t, expected.GetCapella(), decoded)
+	})
+	t.Run("Blinded Capella", func(t *testing.T) {
+		var b *SignedBlindedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(blindedCapellaBlock), &b))
+		expected, err := b.ToGeneric()
+		require.NoError(t, err)
+		sszBytes, err := b.MarshalSSZ()
+		require.NoError(t, err)
+		decoded := &eth.SignedBlindedBeaconBlockCapella{}
+		require.NoError(t, decoded.UnmarshalSSZ(sszBytes))
+		assert.DeepSSZEqual(t, expected.GetBlindedCapella(), decoded)
+	})
+}
+
+func TestConvertAtts(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		_, err := convertAtts(b.Message.Body.Attestations)
+		require.NoError(t, err)
+	})
+	t.Run("source epoch greater than target epoch", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Body.Attestations[0].Data.Source.Epoch = "2"
+		b.Message.Body.Attestations[0].Data.Target.Epoch = "1"
+		_, err := convertAtts(b.Message.Body.Attestations)
+		assert.ErrorContains(t, "invalid b.Message.Body.Attestations[0].Data.Source.Epoch: source epoch 2 is greater than target epoch 1", err)
+	})
+	t.Run("bad checkpoint root", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Body.Attestations[0].Data.Target.Root = "0x01"
+		_, err := convertAtts(b.Message.Body.Attestations)
+		assert.ErrorContains(t, "invalid b.Message.Body.Attestations[0].Data.Target.Root: root has length 1 bytes, expected 32 bytes", err)
+	})
+}
+
+func TestDecodeTransactions(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var b *SignedBeaconBlockBellatrix
+		require.NoError(t, json.Unmarshal([]byte(bellatrixBlock), &b))
+		b.Message.Body.ExecutionPayload.Transactions = []string{"0x01", "0x0203"}
+		_, err := b.ToGeneric()
+		require.NoError(t, err)
+	})
+	t.Run("too many transactions", func(t *testing.T) {
+		var b *SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+		// The transactions are not valid hex, so the error proves the count is checked before decoding.
+		b.Message.Body.ExecutionPayload.Transactions = make([]string, fieldparams.MaxTxsPerPayloadLength+1)
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, fmt.Sprintf("%d transactions exceed the maximum of %d", fieldparams.MaxTxsPerPayloadLength+1, fieldparams.MaxTxsPerPayloadLength), err)
+	})
+}
+
+func TestToGeneric_FieldError(t *testing.T) {
+	var b *SignedBeaconBlock
+	require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+	b.Message.Body.Attestations[0].Data.Slot = "foo"
+	_, err := b.ToGeneric()
+	require.ErrorContains(t, "could not decode b.Message.Body.Attestations[0].Data.Slot", err)
+	var fieldErr *fieldError
+	require.Equal(t, true, errors.As(err, &fieldErr))
+	assert.Equal(t, "b.Message.Body.Attestations[0].Data.Slot", fieldErr.field)
+}
+
+func TestConversionErrorField(t *testing.T) {
+	tests := []struct {
+		field string
+		label string
+	}{
+		{field: "b.Signature", label: "b.Signature"},
+		{field: "b.Message.ParentRoot", label: "b.Message.ParentRoot"},
+		{field: "b.Message.Body.AttesterSlashings[1].Attestation1.AttestingIndices[12]", label: "b.Message.Body.AttesterSlashings[].Attestation1.AttestingIndices[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			assert.Equal(t, tt.label, conversionErrorField(tt.field))
+		})
+	}
+}
+
+func TestDecodeCheckpoint(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		c, err := decodeCheckpoint("foo", &Checkpoint{Epoch: "1", Root: hexutil.Encode(make([]byte, 32))})
+		require.NoError(t, err)
+		assert.Equal(t, primitives.Epoch(1), c.Epoch)
+		assert.DeepEqual(t, make([]byte, 32), c.Root)
+	})
+	t.Run("bad epoch", func(t *testing.T) {
+		_, err := decodeCheckpoint("foo", &Checkpoint{Epoch: "foo", Root: hexutil.Encode(make([]byte, 32))})
+		assert.ErrorContains(t, "could not decode foo.Epoch", err)
+	})
+	t.Run("bad root", func(t *testing.T) {
+		_, err := decodeCheckpoint("foo", &Checkpoint{Epoch: "1", Root: hexutil.Encode(make([]byte, 31))})
+		assert.ErrorContains(t, "invalid foo.Root: root has length 31 bytes, expected 32 bytes", err)
+	})
+}
+
+func TestDecodeLogsBloom(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		bloom, err := decodeLogsBloom("foo", hexutil.Encode(make([]byte, fieldparams.LogsBloomLength)))
+		require.NoError(t, err)
+		assert.Equal(t, fieldparams.LogsBloomLength, len(bloom))
+	})
+	t.Run("too short", func(t *testing.T) {
+		_, err := decodeLogsBloom("foo", hexutil.Encode(make([]byte, fieldparams.LogsBloomLength-1)))
+		assert.ErrorContains(t, "invalid foo: logs bloom has length 255 bytes, expected 256 bytes", err)
+	})
+	t.Run("too long", func(t *testing.T) {
+		_, err := decodeLogsBloom("foo", hexutil.Encode(make([]byte, fieldparams.LogsBloomLength+1)))
+		assert.ErrorContains(t, "invalid foo: logs bloom has length 257 bytes, expected 256 bytes", err)
+	})
+}
+
+func TestDecodeExtraData(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		extraData, err := decodeExtraData("foo", "0x")
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(extraData))
+	})
+	t.Run("max length", func(t *testing.T) {
+		extraData, err := decodeExtraData("foo", hexutil.Encode(make([]byte, maxExtraDataLength)))
+		require.NoError(t, err)
+		assert.Equal(t, maxExtraDataLength, len(extraData))
+	})
+	t.Run("too long", func(t *testing.T) {
+		_, err := decodeExtraData("foo", hexutil.Encode(make([]byte, maxExtraDataLength+1)))
+		assert.ErrorContains(t, "invalid foo: extra data has length 33 bytes, exceeding the maximum of 32 bytes", err)
+	})
+}
+
+func TestToGeneric_ExecutionPayloadLengths(t *testing.T) {
+	t.Run("Bellatrix logs bloom", func(t *testing.T) {
+		var b *SignedBeaconBlockBellatrix
+		require.NoError(t, json.Unmarshal([]byte(bellatrixBlock), &b))
+		b.Message.Body.ExecutionPayload.LogsBloom = "0x01"
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "invalid b.Message.Body.ExecutionPayload.LogsBloom: logs bloom has length 1 bytes", err)
+	})
+	t.Run("Capella extra data", func(t *testing.T) {
+		var b *SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+		b.Message.Body.ExecutionPayload.ExtraData = hexutil.Encode(make([]byte, 64))
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "invalid b.Message.Body.ExecutionPayload.ExtraData: extra data has length 64 bytes", err)
+	})
+	t.Run("blinded Capella logs bloom", func(t *testing.T) {
+		var b *SignedBlindedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(blindedCapellaBlock), &b))
+		b.Message.Body.ExecutionPayloadHeader.LogsBloom = hexutil.Encode(make([]byte, 512))
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "invalid b.Message.Body.ExecutionPayloadHeader.LogsBloom: logs bloom has length 512 bytes", err)
+	})
+	t.Run("blinded Bellatrix extra data", func(t *testing.T) {
+		var b *SignedBlindedBeaconBlockBellatrix
+		require.NoError(t, json.Unmarshal([]byte(blindedBellatrixBlock), &b))
+		b.Message.Body.ExecutionPayloadHeader.ExtraData = hexutil.Encode(make([]byte, 33))
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "invalid b.Message.Body.ExecutionPayloadHeader.ExtraData: extra data has length 33 bytes", err)
+	})
+}
+
+func TestConvertInternalPayloadHeader(t *testing.T) {
+	t.Run("Bellatrix", func(t *testing.T) {
+		var b *SignedBlindedBeaconBlockBellatrix
+		require.NoError(t, json.Unmarshal([]byte(blindedBellatrixBlock), &b))
+		g, err := b.ToGeneric()
+		require.NoError(t, err)
+		blk := convertInternalBlindedBeaconBlockBellatrix(g.GetBlindedBellatrix().Block)
+		assert.DeepEqual(t, b.Message.Body.ExecutionPayloadHeader, blk.Body.ExecutionPayloadHeader)
+	})
+	t.Run("Capella", func(t *testing.T) {
+		var b *SignedBlindedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(blindedCapellaBlock), &b))
+		g, err := b.ToGeneric()
+		require.NoError(t, err)
+		blk := convertInternalBlindedBeaconBlockCapella(g.GetBlindedCapella().Block)
+		assert.DeepEqual(t, b.Message.Body.ExecutionPayloadHeader, blk.Body.ExecutionPayloadHeader)
+	})
+}
+
+func TestDecodeSlot(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		slot, err := decodeSlot("foo", strconv.FormatUint(maxSlot(), 10))
+		require.NoError(t, err)
+		assert.Equal(t, maxSlot(), slot)
+	})
+	t.Run("max uint64", func(t *testing.T) {
+		_, err := decodeSlot("foo", strconv.FormatUint(math.MaxUint64, 10))
+		assert.ErrorContains(t, fmt.Sprintf("invalid foo: slot %d exceeds the maximum of %d", uint64(math.MaxUint64), maxSlot()), err)
+	})
+	t.Run("overflow", func(t *testing.T) {
+		_, err := decodeSlot("foo", "18446744073709551616")
+		assert.ErrorContains(t, "could not decode foo", err)
+	})
+}
+
+func TestDecodeEpoch(t *testing.T) {
+	maxEpoch := maxSlot() / uint64(params.BeaconConfig().SlotsPerEpoch)
+	t.Run("ok", func(t *testing.T) {
+		epoch, err := decodeEpoch("foo", strconv.FormatUint(maxEpoch, 10))
+		require.NoError(t, err)
+		assert.Equal(t, maxEpoch, epoch)
+	})
+	t.Run("max uint64", func(t *testing.T) {
+		_, err := decodeEpoch("foo", strconv.FormatUint(math.MaxUint64, 10))
+		assert.ErrorContains(t, fmt.Sprintf("invalid foo: epoch %d exceeds the maximum of %d", uint64(math.MaxUint64), maxEpoch), err)
+	})
+}
+
+func TestDecodeValidatorIndex(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		index, err := decodeValidatorIndex("foo", strconv.FormatUint(fieldparams.ValidatorRegistryLimit-1, 10))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(fieldparams.ValidatorRegistryLimit-1), index)
+	})
+	t.Run("registry limit", func(t *testing.T) {
+		_, err := decodeValidatorIndex("foo", strconv.FormatUint(fieldparams.ValidatorRegistryLimit, 10))
+		assert.ErrorContains(t, "invalid foo: validator index", err)
+	})
+	t.Run("max uint64", func(t *testing.T) {
+		_, err := decodeValidatorIndex("foo", strconv.FormatUint(math.MaxUint64, 10))
+		assert.ErrorContains(t, "invalid foo: validator index", err)
+	})
+}
+
+func TestToGeneric_MaxUint64(t *testing.T) {
+	maxUint64 := strconv.FormatUint(math.MaxUint64, 10)
+	t.Run("slot", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Slot = maxUint64
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "invalid b.Message.Slot: slot", err)
+	})
+	t.Run("proposer index", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.ProposerIndex = maxUint64
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "invalid b.Message.ProposerIndex: validator index", err)
+	})
+	t.Run("attestation target epoch", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Body.Attestations[0].Data.Target.Epoch = maxUint64
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "invalid b.Message.Body.Attestations[0].Data.Target.Epoch: epoch", err)
+	})
+}
+
+func TestToGeneric_NilBlock(t *testing.T) {
+	type genericBlock interface {
+		ToGeneric() (*eth.GenericSignedBeaconBlock, error)
+	}
+	blocks := map[string]genericBlock{
+		"Phase 0":           (*SignedBeaconBlock)(nil),
+		"Altair":            (*SignedBeaconBlockAltair)(nil),
+		"Bellatrix":         (*SignedBeaconBlockBellatrix)(nil),
+		"Blinded Bellatrix": (*SignedBlindedBeaconBlockBellatrix)(nil),
+		"Capella":           (*SignedBeaconBlockCapella)(nil),
+		"Blinded Capella":   (*SignedBlindedBeaconBlockCapella)(nil),
+	}
+	for name, b := range blocks {
+		t.Run(name, func(t *testing.T) {
+			_, err := b.ToGeneric()
+			assert.ErrorContains(t, "nil block", err)
+		})
+	}
+	t.Run("null message", func(t *testing.T) {
+		var b *SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(`{"message": null, "signature": "0x`+strings.Repeat("00", 96)+`"}`), &b))
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "b.Message.Slot", err)
+	})
+}
+
+func TestHashTreeRoot(t *testing.T) {
+	t.Run("Phase 0", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		g, err := b.ToGeneric()
+		require.NoError(t, err)
+		expected, err := g.GetPhase0().Block.HashTreeRoot()
+		require.NoError(t, err)
+		root, err := b.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, expected, root)
+	})
+	t.Run("Altair", func(t *testing.T) {
+		var b *SignedBeaconBlockAltair
+		require.NoError(t, json.Unmarshal([]byte(altairBlock), &b))
+		g, err := b.ToGeneric()
+		require.NoError(t, err)
+		expected, err := g.GetAltair().Block.HashTreeRoot()
+		require.NoError(t, err)
+		root, err := b.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, expected, root)
+	})
+	t.Run("Bellatrix", func(t *testing.T) {
+		var b *SignedBeaconBlockBellatrix
+		require.NoError(t, json.Unmarshal([]byte(bellatrixBlock), &b))
+		g, err := b.ToGeneric()
+		require.NoError(t, err)
+		expected, err := g.GetBellatrix().Block.HashTreeRoot()
+		require.NoError(t, err)
+		root, err := b.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, expected, root)
+	})
+	t.Run("Bellatrix blinded", func(t *testing.T) {
+		var b *SignedBlindedBeaconBlockBellatrix
+		require.NoError(t, json.Unmarshal([]byte(blindedBellatrixBlock), &b))
+		g, err := b.ToGeneric()
+		require.NoError(t, err)
+		expected, err := g.GetBlindedBellatrix().Block.HashTreeRoot()
+		require.NoError(t, err)
+		root, err := b.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, expected, root)
+	})
+	t.Run("Capella", func(t *testing.T) {
+		var b *SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+		g, err := b.ToGeneric()
+		require.NoError(t, err)
+		expected, err := g.GetCapella().Block.HashTreeRoot()
+		require.NoError(t, err)
+		root, err := b.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, expected, root)
+	})
+	t.Run("Capella blinded", func(t *testing.T) {
+		var b *SignedBlindedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(blindedCapellaBlock), &b))
+		g, err := b.ToGeneric()
+		require.NoError(t, err)
+		expected, err := g.GetBlindedCapella().Block.HashTreeRoot()
+		require.NoError(t, err)
+		root, err := b.HashTreeRoot()
+		require.NoError(t, err)
+		assert.Equal(t, expected, root)
+	})
+	t.Run("invalid block", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Slot = "foo"
+		_, err := b.HashTreeRoot()
+		assert.ErrorContains(t, "could not decode b.Message.Slot", err)
+	})
+}
+
+func TestDecodeSyncCommitteeBits(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var b *SignedBeaconBlockAltair
+		require.NoError(t, json.Unmarshal([]byte(altairBlock), &b))
+		_, err := b.ToGeneric()
+		require.NoError(t, err)
+	})
+	t.Run("too short", func(t *testing.T) {
+		var b *SignedBeaconBlockAltair
+		require.NoError(t, json.Unmarshal([]byte(altairBlock), &b))
+		b.Message.Body.SyncAggregate.SyncCommitteeBits = "0x0102"
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "sync committee bits have length 2 bytes, expected 64 bytes", err)
+	})
+	t.Run("too long", func(t *testing.T) {
+		var b *SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+		b.Message.Body.SyncAggregate.SyncCommitteeBits += "00"
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "sync committee bits have length 65 bytes, expected 64 bytes", err)
+	})
+	t.Run("unprefixed", func(t *testing.T) {
+		var b *SignedBeaconBlockAltair
+		require.NoError(t, json.Unmarshal([]byte(altairBlock), &b))
+		b.Message.Body.SyncAggregate.SyncCommitteeBits = b.Message.Body.SyncAggregate.SyncCommitteeBits[2:]
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "b.Message.Body.SyncAggregate.SyncCommitteeBits must be 0x-prefixed hex", err)
+	})
+}
+
+func TestDecodeUint64(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		v, err := decodeUint64("foo", "123")
+		require.NoError(t, err)
+		assert.Equal(t, uint64(123), v)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := decodeUint64("foo", "0x01")
+		assert.ErrorContains(t, "could not decode foo", err)
+	})
+	t.Run("block fields", func(t *testing.T) {
+		tests := []struct {
+			field  string
+			modify func(b *SignedBeaconBlock)
+		}{
+			{field: "b.Message.Body.Attestations[0].Data.Index", modify: func(b *SignedBeaconBlock) { b.Message.Body.Attestations[0].Data.Index = "foo" }},
+			{field: "b.Message.Body.AttesterSlashings[0].Attestation1.Data.Index", modify: func(b *SignedBeaconBlock) { b.Message.Body.AttesterSlashings[0].Attestation1.Data.Index = "foo" }},
+			{field: "b.Message.Body.Eth1Data.DepositCount", modify: func(b *SignedBeaconBlock) { b.Message.Body.Eth1Data.DepositCount = "foo" }},
+			{field: "b.Message.Body.Deposits[0].Amount", modify: func(b *SignedBeaconBlock) { b.Message.Body.Deposits[0].Data.Amount = "foo" }},
+		}
+		for _, tt := range tests {
+			t.Run(tt.field, func(t *testing.T) {
+				var b *SignedBeaconBlock
+				require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+				tt.modify(b)
+				_, err := b.ToGeneric()
+				assert.ErrorContains(t, "could not decode "+tt.field, err)
+				var fieldErr *fieldError
+				require.Equal(t, true, errors.As(err, &fieldErr))
+				assert.Equal(t, tt.field, fieldErr.field)
+			})
+		}
+	})
+}
+
+func TestDecodeHex(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		b, err := decodeHex("foo", "0x0102")
+		require.NoError(t, err)
+		assert.DeepEqual(t, []byte{0x01, 0x02}, b)
+	})
+	t.Run("empty", func(t *testing.T) {
+		b, err := decodeHex("foo", "0x")
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(b))
+	})
+	t.Run("unprefixed", func(t *testing.T) {
+		_, err := decodeHex("foo", "0102")
+		assert.ErrorContains(t, "foo must be 0x-prefixed hex", err)
+	})
+	t.Run("odd length", func(t *testing.T) {
+		_, err := decodeHex("foo", "0x102")
+		assert.ErrorContains(t, "foo must have an even number of hex digits", err)
+	})
+	t.Run("invalid character", func(t *testing.T) {
+		_, err := decodeHex("foo", "0x01zz")
+		assert.ErrorContains(t, "could not decode foo", err)
+	})
+	t.Run("unprefixed block field", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Body.Attestations[0].Signature = b.Message.Body.Attestations[0].Signature[2:]
+		_, err := b.ToGeneric()
+		assert.ErrorContains(t, "b.Message.Body.Attestations[0].Signature must be 0x-prefixed hex", err)
+	})
+}
+
+func TestToGeneric_ShortSignature(t *testing.T) {
+	type genericBlock interface {
+		ToGeneric() (*eth.GenericSignedBeaconBlock, error)
+	}
+	// Dropping the last byte leaves a 95 byte signature.
+	shorten := func(sig string) string {
+		return sig[:len(sig)-2]
+	}
+	tests := []struct {
+		name    string
+		toBlock func(t *testing.T) genericBlock
+	}{
+		{
+			name: "Phase 0",
+			toBlock: func(t *testing.T) genericBlock {
+				var b *SignedBeaconBlock
+				require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+				b.Signature = shorten(b.Signature)
+				return b
+			},
+		},
+		{
+			name: "Altair",
+			toBlock: func(t *testing.T) genericBlock {
+				var b *SignedBeaconBlockAltair
+				require.NoError(t, json.Unmarshal([]byte(altairBlock), &b))
+				b.Signature = shorten(b.Signature)
+				return b
+			},
+		},
+		{
+			name: "Bellatrix",
+			toBlock: func(t *testing.T) genericBlock {
+				var b *SignedBeaconBlockBellatrix
+				require.NoError(t, json.Unmarshal([]byte(bellatrixBlock), &b))
+				b.Signature = shorten(b.Signature)
+				return b
+			},
+		},
+		{
+			name: "Blinded Bellatrix",
+			toBlock: func(t *testing.T) genericBlock {
+				var b *SignedBlindedBeaconBlockBellatrix
+				require.NoError(t, json.Unmarshal([]byte(blindedBellatrixBlock), &b))
+				b.Signature = shorten(b.Signature)
+				return b
+			},
+		},
+		{
+			name: "Capella",
+			toBlock: func(t *testing.T) genericBlock {
+				var b *SignedBeaconBlockCapella
+				require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+				b.Signature = shorten(b.Signature)
+				return b
+			},
+		},
+		{
+			name: "Blinded Capella",
+			toBlock: func(t *testing.T) genericBlock {
+				var b *SignedBlindedBeaconBlockCapella
+				require.NoError(t, json.Unmarshal([]byte(blindedCapellaBlock), &b))
+				b.Signature = shorten(b.Signature)
+				return b
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.toBlock(t).ToGeneric()
+			assert.ErrorContains(t, "invalid b.Signature: signature must be 96 bytes, got 95", err)
+		})
+	}
+}
+
+func TestConvertAttesterSlashings(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		_, err := convertAttesterSlashings(b.Message.Body.AttesterSlashings)
+		require.NoError(t, err)
+	})
+	t.Run("source epoch greater than target epoch in attestation 1", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Body.AttesterSlashings[0].Attestation1.Data.Source.Epoch = "2"
+		b.Message.Body.AttesterSlashings[0].Attestation1.Data.Target.Epoch = "1"
+		_, err := convertAttesterSlashings(b.Message.Body.AttesterSlashings)
+		assert.ErrorContains(t, "invalid b.Message.Body.AttesterSlashings[0].Attestation1.Data.Source.Epoch: source epoch 2 is greater than target epoch 1", err)
+	})
+	t.Run("source epoch greater than target epoch in attestation 2", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Body.AttesterSlashings[0].Attestation2.Data.Source.Epoch = "2"
+		b.Message.Body.AttesterSlashings[0].Attestation2.Data.Target.Epoch = "1"
+		_, err := convertAttesterSlashings(b.Message.Body.AttesterSlashings)
+		assert.ErrorContains(t, "invalid b.Message.Body.AttesterSlashings[0].Attestation2.Data.Source.Epoch: source epoch 2 is greater than target epoch 1", err)
+	})
+	t.Run("unsorted attesting indices", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Body.AttesterSlashings[0].Attestation1.AttestingIndices = []string{"1", "3", "2"}
+		_, err := convertAttesterSlashings(b.Message.Body.AttesterSlashings)
+		assert.ErrorContains(t, "invalid b.Message.Body.AttesterSlashings[0].Attestation1.AttestingIndices: index 2 at position 2 is lower than the previous index 3", err)
+	})
+	t.Run("duplicate attesting indices", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Body.AttesterSlashings[0].Attestation2.AttestingIndices = []string{"1", "2", "2"}
+		_, err := convertAttesterSlashings(b.Message.Body.AttesterSlashings)
+		assert.ErrorContains(t, "invalid b.Message.Body.AttesterSlashings[0].Attestation2.AttestingIndices: index 2 at position 2 is a duplicate", err)
+	})
+}
+
+func TestConvertDeposits(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		require.Equal(t, int(params.BeaconConfig().DepositContractTreeDepth)+1, len(b.Message.Body.Deposits[0].Proof))
+		_, err := convertDeposits(b.Message.Body.Deposits)
+		require.NoError(t, err)
+	})
+	t.Run("proof too short", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Body.Deposits[0].Proof = b.Message.Body.Deposits[0].Proof[1:]
+		_, err := convertDeposits(b.Message.Body.Deposits)
+		assert.ErrorContains(t, "invalid b.Message.Body.Deposits[0].Proof: proof has 32 elements, expected 33", err)
+	})
+}
+
+func TestConvertExits(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		exit := b.Message.Body.VoluntaryExits[0]
+		exit.Message.ValidatorIndex = "2"
+		b.Message.Body.VoluntaryExits = append(b.Message.Body.VoluntaryExits, exit)
+		exits, err := convertExits(b.Message.Body.VoluntaryExits)
+		require.NoError(t, err)
+		assert.Equal(t, 2, len(exits))
+	})
+	t.Run("duplicate validator index", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.NoError(t, json.Unmarshal([]byte(phase0Block), &b))
+		b.Message.Body.VoluntaryExits = append(b.Message.Body.VoluntaryExits, b.Message.Body.VoluntaryExits[0])
+		_, err := convertExits(b.Message.Body.VoluntaryExits)
+		assert.ErrorContains(t, "b.Message.Body.VoluntaryExits[1] is a duplicate of b.Message.Body.VoluntaryExits[0] for validator index 1", err)
+	})
+}
+
+func TestConvertBlsChanges(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		var b *SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+		_, err := convertBlsChanges(b.Message.Body.BlsToExecutionChanges)
+		require.NoError(t, err)
+	})
+	t.Run("wrong length address", func(t *testing.T) {
+		var b *SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+		b.Message.Body.BlsToExecutionChanges[0].Message.ToExecutionAddress = "0xabcf8e0d4e9587369b2301d0790347320302cc"
+		_, err := convertBlsChanges(b.Message.Body.BlsToExecutionChanges)
+		assert.ErrorContains(t, "invalid b.Message.Body.BlsToExecutionChanges[0].Message.ToExecutionAddress: address has length 19 bytes, expected 20 bytes", err)
+	})
+	t.Run("wrong length pubkey", func(t *testing.T) {
+		var b *SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+		b.Message.Body.BlsToExecutionChanges[0].Message.FromBlsPubkey += "00"
+		_, err := convertBlsChanges(b.Message.Body.BlsToExecutionChanges)
+		assert.ErrorContains(t, "invalid b.Message.Body.BlsToExecutionChanges[0].Message.FromBlsPubkey: pubkey has length 49 bytes, expected 48 bytes", err)
+	})
+	t.Run("duplicate validator index", func(t *testing.T) {
+		var b *SignedBeaconBlockCapella
+		require.NoError(t, json.Unmarshal([]byte(capellaBlock), &b))
+		b.Message.Body.BlsToExecutionChanges = append(b.Message.Body.BlsToExecutionChanges, b.Message.Body.BlsToExecutionChanges[0])
+		_, err := convertBlsChanges(b.Message.Body.BlsToExecutionChanges)
+		assert.ErrorContains(t, "b.Message.Body.BlsToExecutionChanges[1] is a duplicate of b.Message.Body.BlsToExecutionChanges[0] for validator index 1", err)
+	})
+}
+
+func TestUint256RoundTrip(t *testing.T) {
+	for _, num := range []string{"0", "1", "7", "1000000000", "115792089237316195423570985008687907853269984665640564039457584007913129639935"} {
+		b, err := uint256ToHex(num)
+		require.NoError(t, err)
+		assert.Equal(t, 32, len(b))
+		assert.Equal(t, num, hexToUint256Decimal(b))
+	}
+}
+
+func TestFromGeneric(t *testing.T) {
+	t.Run("Phase 0", func(t *testing.T) {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = 123
+		v, ver, err := FromGeneric(&eth.GenericSignedBeaconBlock{Block: &eth.GenericSignedBeaconBlock_Phase0{Phase0: b}})
+		require.NoError(t, err)
+		assert.Equal(t, version.String(version.Phase0), ver)
+		blk, ok := v.(*SignedBeaconBlock)
+		require.Equal(t, true, ok)
+		assert.Equal(t, "123", blk.Message.Slot)
+		assert.Equal(t, hexutil.Encode(b.Signature), blk.Signature)
+	})
+	t.Run("Altair", func(t *testing.T) {
+		b := util.NewBeaconBlockAltair()
+		b.Block.Slot = 123
+		v, ver, err := FromGeneric(&eth.GenericSignedBeaconBlock{Block: &eth.GenericSignedBeaconBlock_Altair{Altair: b}})
+		require.NoError(t, err)
+		assert.Equal(t, version.String(version.Altair), ver)
+		blk, ok := v.(*SignedBeaconBlockAltair)
+		require.Equal(t, true, ok)
+		assert.Equal(t, "123", blk.Message.Slot)
+		assert.Equal(t, hexutil.Encode(b.Block.Body.SyncAggregate.SyncCommitteeBits), blk.Message.Body.SyncAggregate.SyncCommitteeBits)
+	})
+	t.Run("Bellatrix", func(t *testing.T) {
+		b := util.NewBeaconBlockBellatrix()
+		b.Block.Slot = 123
+		b.Block.Body.ExecutionPayload.Transactions = [][]byte{{0x01, 0x02}}
+		v, ver, err := FromGeneric(&eth.GenericSignedBeaconBlock{Block: &eth.GenericSignedBeaconBlock_Bellatrix{Bellatrix: b}})
+		require.NoError(t, err)
+		assert.Equal(t, version.String(version.Bellatrix), ver)
+		blk, ok := v.(*SignedBeaconBlockBellatrix)
+		require.Equal(t, true, ok)
+		assert.Equal(t, "123", blk.Message.Slot)
+		require.Equal(t, 1, len(blk.Message.Body.ExecutionPayload.Transactions))
+		assert.Equal(t, "0x0102", blk.Message.Body.ExecutionPayload.Transactions[0])
+	})
+	t.Run("Bellatrix base fee round trip", func(t *testing.T) {
+		b := util.NewBeaconBlockBellatrix()
+		b.Block.Body.ExecutionPayload.BaseFeePerGas = bytesutil.PadTo([]byte{0x07, 0x01}, 32)
+		v, _, err := FromGeneric(&eth.GenericSignedBeaconBlock{Block: &eth.GenericSignedBeaconBlock_Bellatrix{Bellatrix: b}})
+		require.NoError(t, err)
+		blk, ok := v.(*SignedBeaconBlockBellatrix)
+		require.Equal(t, true, ok)
+		assert.Equal(t, "263", blk.Message.Body.ExecutionPayload.BaseFeePerGas)
+		generic, err := blk.ToGeneric()
+		require.NoError(t, err)
+		assert.DeepEqual(t, b.Block.Body.ExecutionPayload.BaseFeePerGas, generic.GetBellatrix().Block.Body.ExecutionPayload.BaseFeePerGas)
+	})
+	t.Run("Blinded Bellatrix", func(t *testing.T) {
+		b := util.NewBlindedBeaconBlockBellatrix()
+		b.Block.Slot = 123
+		v, ver, err := FromGeneric(&eth.GenericSignedBeaconBlock{Block: &eth.GenericSignedBeaconBlock_BlindedBellatrix{BlindedBellatrix: b}})
+		require.NoError(t, err)
+		assert.Equal(t, version.String(version.Bellatrix), ver)
+		blk, ok := v.(*SignedBlindedBeaconBlockBellatrix)
+		require.Equal(t, true, ok)
+		assert.Equal(t, "123", blk.Message.Slot)
+		assert.Equal(t, hexutil.Encode(b.Block.Body.ExecutionPayloadHeader.TransactionsRoot), blk.Message.Body.ExecutionPayloadHeader.TransactionsRoot)
+	})
+	t.Run("Capella", func(t *testing.T) {
+		b := util.NewBeaconBlockCapella()
+		b.Block.Slot = 123
+		b.Block.Body.ExecutionPayload.Withdrawals = []*enginev1.Withdrawal{{Index: 1, ValidatorIndex: 2, Address: make([]byte, 20), Amount: 3}}
+		v, ver, err := FromGeneric(&eth.GenericSignedBeaconBlock{Block: &eth.GenericSignedBeaconBlock_Capella{Capella: b}})
+		require.NoError(t, err)
+		assert.Equal(t, version.String(version.Capella), ver)
+		blk, ok := v.(*SignedBeaconBlockCapella)
+		require.Equal(t, true, ok)
+		assert.Equal(t, "123", blk.Message.Slot)
+		require.Equal(t, 1, len(blk.Message.Body.ExecutionPayload.Withdrawals))
+		assert.DeepEqual(t, Withdrawal{
+			WithdrawalIndex:  "1",
+			ValidatorIndex:   "2",
+			ExecutionAddress: hexutil.Encode(make([]byte, 20)),
+			Amount:           "3",
+		}, blk.Message.Body.ExecutionPayload.Withdrawals[0])
+	})
+	t.Run("Blinded Capella", func(t *testing.T) {
+		b := util.NewBlindedBeaconBlockCapella()
+		b.Block.Slot = 123
+		v, ver, err := FromGeneric(&eth.GenericSignedBeaconBlock{Block: &eth.GenericSignedBeaconBlock_BlindedCapella{BlindedCapella: b}})
+		require.NoError(t, err)
+		assert.Equal(t, version.String(version.Capella), ver)
+		blk, ok := v.(*SignedBlindedBeaconBlockCapella)
+		require.Equal(t, true, ok)
+		assert.Equal(t, "123", blk.Message.Slot)
+		assert.Equal(t, hexutil.Encode(b.Block.Body.ExecutionPayloadHeader.WithdrawalsRoot), blk.Message.Body.ExecutionPayloadHeader.WithdrawalsRoot)
+	})
+	t.Run("nil block", func(t *testing.T) {
+		_, _, err := FromGeneric(nil)
+		assert.ErrorContains(t, "nil block", err)
+	})
+	t.Run("unsupported block type", func(t *testing.T) {
+		_, _, err := FromGeneric(&eth.GenericSignedBeaconBlock{})
+		assert.ErrorContains(t, "unsupported block type", err)
+	})
+}
+
+func TestFromGeneric_BaseFeeRoundTrip(t *testing.T) {
+	baseFee := bytesutil.PadTo([]byte{0x07, 0x01}, 32)
+	blindedBellatrix := util.NewBlindedBeaconBlockBellatrix()
+	blindedBellatrix.Block.Body.ExecutionPayloadHeader.BaseFeePerGas = baseFee
+	capella := util.NewBeaconBlockCapella()
+	capella.Block.Body.ExecutionPayload.BaseFeePerGas = baseFee
+	blindedCapella := util.NewBlindedBeaconBlockCapella()
+	blindedCapella.Block.Body.ExecutionPayloadHeader.BaseFeePerGas = baseFee
+
+	tests := []struct {
+		name    string
+		generic *eth.GenericSignedBeaconBlock
+		baseFee func(*eth.GenericSignedBeaconBlock) []byte
+	}{
+		{
+			name:    "Blinded Bellatrix",
+			generic: &eth.GenericSignedBeaconBlock{Block: &eth.GenericSignedBeaconBlock_BlindedBellatrix{BlindedBellatrix: blindedBellatrix}},
+			baseFee: func(g *eth.GenericSignedBeaconBlock) []byte {
+				return g.GetBlindedBellatrix().Block.Body.ExecutionPayloadHeader.BaseFeePerGas
+			},
+		},
+		{
+			name:    "Capella",
+			generic: &eth.GenericSignedBeaconBlock{Block: &eth.GenericSignedBeaconBlock_Capella{Capella: capella}},
+			baseFee: func(g *eth.GenericSignedBeaconBlock) []byte {
+				return g.GetCapella().Block.Body.ExecutionPayload.BaseFeePerGas
+			},
+		},
+		{
+			name:    "Blinded Capella",
+			generic: &eth.GenericSignedBeaconBlock{Block: &eth.GenericSignedBeaconBlock_BlindedCapella{BlindedCapella: blindedCapella}},
+			baseFee: func(g *eth.GenericSignedBeaconBlock) []byte {
+				return g.GetBlindedCapella().Block.Body.ExecutionPayloadHeader.BaseFeePerGas
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, _, err := FromGeneric(tt.generic)
+			require.NoError(t, err)
+			blk, ok := v.(interface {
+				ToGeneric() (*eth.GenericSignedBeaconBlock, error)
+			})
+			require.Equal(t, true, ok)
+			generic, err := blk.ToGeneric()
+			require.NoError(t, err)
+			assert.DeepEqual(t, baseFee, tt.baseFee(generic))
+		})
+	}
+}
@@ -3,15 +3,25 @@ package shared
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	http2 "github.com/prysmaticlabs/prysm/v4/network/http"
 )
 
+// maxSyncRetryAfter caps the Retry-After estimate returned while syncing, so that clients
+// periodically re-check the sync status even when the node is far behind the chain head.
+const maxSyncRetryAfter = 10 * time.Minute
+
 func ValidateHex(w http.ResponseWriter, name string, s string) bool {
 	if s == "" {
 		errJson := &http2.DefaultErrorJson{
@@ -53,6 +63,105 @@ func ValidateUint(w http.ResponseWriter, name string, s string) (uint64, bool) {
 	return v, true
 }
 
+// IsMethodAllowed checks whether the request uses the given HTTP method. Otherwise it writes out
+// a 405 response with the Allow header set to the expected method.
+func IsMethodAllowed(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	errJson := &http2.DefaultErrorJson{
+		Message: fmt.Sprintf("Method %s is not allowed, use %s", r.Method, method),
+		Code:    http.StatusMethodNotAllowed,
+	}
+	http2.WriteError(w, errJson)
+	return false
+}
+
+// IsNotModified sets the ETag header of the response to the given entity tag, and checks whether
+// the request's If-None-Match header matches it. If so, a 304 response without a body is written out.
+func IsNotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	for _, t := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == etag || t == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotModifiedSince sets the Last-Modified header of the response to the given time, and checks whether
+// the request's If-Modified-Since header is not before it. If so, a 304 response without a body is written out.
+// If-Modified-Since is ignored when the request has an If-None-Match header, which is the more precise of the two.
+func IsNotModifiedSince(w http.ResponseWriter, r *http.Request, lastModified time.Time) bool {
+	w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	if r.Header.Get("If-None-Match") != "" {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// HTTP dates have a resolution of one second.
+	if lastModified.Truncate(time.Second).After(since) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// Pagination is the page of items requested from a list endpoint through the `offset` and `limit` query parameters.
+type Pagination struct {
+	Offset uint64
+	Limit  uint64
+}
+
+// PaginationFromRequest reads the optional `offset` and `limit` query parameters of a list endpoint.
+// The limit defaults to the configured default page size and can't exceed the maximum RPC page size.
+// An error response is written when either parameter is invalid.
+func PaginationFromRequest(w http.ResponseWriter, r *http.Request) (Pagination, bool) {
+	p := Pagination{Limit: uint64(params.BeaconConfig().DefaultPageSize)}
+	query := r.URL.Query()
+	if rawOffset := query.Get("offset"); rawOffset != "" {
+		offset, ok := ValidateUint(w, "offset", rawOffset)
+		if !ok {
+			return Pagination{}, false
+		}
+		p.Offset = offset
+	}
+	if rawLimit := query.Get("limit"); rawLimit != "" {
+		limit, ok := ValidateUint(w, "limit", rawLimit)
+		if !ok {
+			return Pagination{}, false
+		}
+		maxLimit := uint64(cmd.Get().MaxRPCPageSize)
+		if limit == 0 || limit > maxLimit {
+			errJson := &http2.DefaultErrorJson{
+				Message: fmt.Sprintf("limit must be between 1 and %d", maxLimit),
+				Code:    http.StatusBadRequest,
+			}
+			http2.WriteError(w, errJson)
+			return Pagination{}, false
+		}
+		p.Limit = limit
+	}
+	return p, true
+}
+
+// Paginate returns the items that fall within the requested page.
+func Paginate[T any](items []T, p Pagination) []T {
+	if p.Offset >= uint64(len(items)) {
+		return []T{}
+	}
+	end := p.Offset + p.Limit
+	if end > uint64(len(items)) {
+		end = uint64(len(items))
+	}
+	return items[p.Offset:end]
+}
+
 // IsSyncing checks whether the beacon node is currently syncing and writes out the sync status.
 func IsSyncing(
 	ctx context.Context,
@@ -67,6 +176,7 @@ func IsSyncing(
 	}
 
 	headSlot := headFetcher.HeadSlot()
+	syncDistance := timeFetcher.CurrentSlot() - headSlot
 	isOptimistic, err := optimisticModeFetcher.IsOptimistic(ctx)
 	if err != nil {
 		errJson := &http2.DefaultErrorJson{
@@ -79,7 +189,7 @@ func IsSyncing(
 	syncDetails := &SyncDetailsContainer{
 		Data: &SyncDetails{
 			HeadSlot:     strconv.FormatUint(uint64(headSlot), 10),
-			SyncDistance: strconv.FormatUint(uint64(timeFetcher.CurrentSlot()-headSlot), 10),
+			SyncDistance: strconv.FormatUint(uint64(syncDistance), 10),
 			IsSyncing:    true,
 			IsOptimistic: isOptimistic,
 		},
@@ -90,9 +200,24 @@ func IsSyncing(
 	if err == nil {
 		msg += " Details: " + string(details)
 	}
+	w.Header().Set("Retry-After", strconv.FormatUint(syncRetryAfter(syncDistance), 10))
 	errJson := &http2.DefaultErrorJson{
 		Message: msg,
 		Code:    http.StatusServiceUnavailable}
 	http2.WriteError(w, errJson)
 	return true
 }
+
+// syncRetryAfter estimates the number of seconds after which a syncing node might be able to serve requests,
+// based on the number of slots it is behind the chain head.
+func syncRetryAfter(syncDistance primitives.Slot) uint64 {
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	maxSeconds := uint64(maxSyncRetryAfter.Seconds())
+	if syncDistance == 0 {
+		return secondsPerSlot
+	}
+	if uint64(syncDistance) >= maxSeconds/secondsPerSlot {
+		return maxSeconds
+	}
+	return uint64(syncDistance) * secondsPerSlot
+}
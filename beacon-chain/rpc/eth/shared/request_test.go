@@ -0,0 +1,203 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	chainMock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	syncMock "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestIsMethodAllowed(t *testing.T) {
+	t.Run("allowed", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://foo.example", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		assert.Equal(t, true, IsMethodAllowed(writer, request, http.MethodPost))
+		assert.Equal(t, "", writer.Header().Get("Allow"))
+	})
+	t.Run("not allowed", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		assert.Equal(t, false, IsMethodAllowed(writer, request, http.MethodPost))
+		assert.Equal(t, http.StatusMethodNotAllowed, writer.Code)
+		assert.Equal(t, http.MethodPost, writer.Header().Get("Allow"))
+		assert.StringContains(t, "Method GET is not allowed, use POST", writer.Body.String())
+	})
+}
+
+func TestIsNotModified(t *testing.T) {
+	t.Run("no header", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		assert.Equal(t, false, IsNotModified(writer, request, `"foo"`))
+		assert.Equal(t, `"foo"`, writer.Header().Get("ETag"))
+	})
+	t.Run("matching", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		request.Header.Set("If-None-Match", `"bar", W/"foo"`)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		assert.Equal(t, true, IsNotModified(writer, request, `"foo"`))
+		assert.Equal(t, http.StatusNotModified, writer.Code)
+	})
+	t.Run("not matching", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		request.Header.Set("If-None-Match", `"bar"`)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		assert.Equal(t, false, IsNotModified(writer, request, `"foo"`))
+	})
+}
+
+func TestIsNotModifiedSince(t *testing.T) {
+	lastModified := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	t.Run("no header", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		assert.Equal(t, false, IsNotModifiedSince(writer, request, lastModified))
+		assert.Equal(t, "Thu, 01 Jun 2023 12:00:00 GMT", writer.Header().Get("Last-Modified"))
+	})
+	t.Run("not modified", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		request.Header.Set("If-Modified-Since", "Thu, 01 Jun 2023 12:00:00 GMT")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		assert.Equal(t, true, IsNotModifiedSince(writer, request, lastModified))
+		assert.Equal(t, http.StatusNotModified, writer.Code)
+	})
+	t.Run("modified", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		request.Header.Set("If-Modified-Since", "Thu, 01 Jun 2023 11:59:59 GMT")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		assert.Equal(t, false, IsNotModifiedSince(writer, request, lastModified))
+	})
+	t.Run("invalid date", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		request.Header.Set("If-Modified-Since", "yesterday")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		assert.Equal(t, false, IsNotModifiedSince(writer, request, lastModified))
+	})
+	t.Run("If-None-Match takes precedence", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		request.Header.Set("If-None-Match", `"foo"`)
+		request.Header.Set("If-Modified-Since", "Thu, 01 Jun 2023 12:00:00 GMT")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		assert.Equal(t, false, IsNotModifiedSince(writer, request, lastModified))
+	})
+}
+
+func TestPaginationFromRequest(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		p, ok := PaginationFromRequest(writer, request)
+		require.Equal(t, true, ok)
+		assert.Equal(t, uint64(0), p.Offset)
+		assert.Equal(t, uint64(params.BeaconConfig().DefaultPageSize), p.Limit)
+	})
+	t.Run("limit and offset", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example?offset=5&limit=10", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		p, ok := PaginationFromRequest(writer, request)
+		require.Equal(t, true, ok)
+		assert.Equal(t, uint64(5), p.Offset)
+		assert.Equal(t, uint64(10), p.Limit)
+	})
+	t.Run("over cap", func(t *testing.T) {
+		maxLimit := cmd.Get().MaxRPCPageSize
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example?limit="+strconv.Itoa(maxLimit+1), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		_, ok := PaginationFromRequest(writer, request)
+		assert.Equal(t, false, ok)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "limit must be between 1 and "+strconv.Itoa(maxLimit), writer.Body.String())
+	})
+	t.Run("zero limit", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example?limit=0", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		_, ok := PaginationFromRequest(writer, request)
+		assert.Equal(t, false, ok)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("invalid offset", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example?offset=-1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		_, ok := PaginationFromRequest(writer, request)
+		assert.Equal(t, false, ok)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		assert.StringContains(t, "offset is invalid", writer.Body.String())
+	})
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4}
+	assert.DeepEqual(t, []int{0, 1}, Paginate(items, Pagination{Offset: 0, Limit: 2}))
+	assert.DeepEqual(t, []int{3, 4}, Paginate(items, Pagination{Offset: 3, Limit: 10}))
+	assert.DeepEqual(t, []int{}, Paginate(items, Pagination{Offset: 5, Limit: 2}))
+	assert.DeepEqual(t, []int{}, Paginate([]int(nil), Pagination{Offset: 0, Limit: 2}))
+}
+
+func TestIsSyncing(t *testing.T) {
+	t.Run("not syncing", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		syncing := IsSyncing(context.Background(), writer, &syncMock.Sync{IsSyncing: false}, nil, nil, nil)
+		assert.Equal(t, false, syncing)
+		assert.Equal(t, "", writer.Header().Get("Retry-After"))
+	})
+	t.Run("syncing", func(t *testing.T) {
+		st, err := util.NewBeaconState()
+		require.NoError(t, err)
+		require.NoError(t, st.SetSlot(100))
+		currentSlot := primitives.Slot(110)
+		chainService := &chainMock.ChainService{State: st, Slot: &currentSlot}
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		syncing := IsSyncing(context.Background(), writer, &syncMock.Sync{IsSyncing: true}, chainService, chainService, chainService)
+		assert.Equal(t, true, syncing)
+		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+		assert.StringContains(t, `\"sync_distance\":\"10\"`, writer.Body.String())
+		expected := strconv.FormatUint(10*params.BeaconConfig().SecondsPerSlot, 10)
+		assert.Equal(t, expected, writer.Header().Get("Retry-After"))
+	})
+	t.Run("syncing far behind", func(t *testing.T) {
+		currentSlot := primitives.Slot(1_000_000)
+		chainService := &chainMock.ChainService{Slot: &currentSlot}
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		syncing := IsSyncing(context.Background(), writer, &syncMock.Sync{IsSyncing: true}, chainService, chainService, chainService)
+		assert.Equal(t, true, syncing)
+		assert.Equal(t, strconv.FormatUint(uint64(maxSyncRetryAfter.Seconds()), 10), writer.Header().Get("Retry-After"))
+	})
+}
+
+func TestSyncRetryAfter(t *testing.T) {
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	assert.Equal(t, secondsPerSlot, syncRetryAfter(0))
+	assert.Equal(t, secondsPerSlot, syncRetryAfter(1))
+	assert.Equal(t, 5*secondsPerSlot, syncRetryAfter(5))
+	assert.Equal(t, uint64(maxSyncRetryAfter.Seconds()), syncRetryAfter(primitives.Slot(^uint64(0))))
+}
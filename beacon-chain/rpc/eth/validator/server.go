@@ -34,4 +34,6 @@ type Server struct {
 	BlockBuilder           builder.BlockBuilder
 	OperationNotifier      operation.Notifier
 	CoreService            *core.Service
+	// DefaultGraffiti tags produced blocks whose graffiti was not requested, e.g. with the operator's branding.
+	DefaultGraffiti [32]byte
 }
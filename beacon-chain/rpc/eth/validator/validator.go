@@ -11,15 +11,20 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
 	rpchelpers "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
 	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
 	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	"github.com/prysmaticlabs/prysm/v4/proto/migration"
@@ -27,7 +32,9 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	log "github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -308,11 +315,14 @@ func (vs *Server) ProduceBlockV2(ctx context.Context, req *ethpbv1.ProduceBlockR
 		// We simply return the error because it's already a gRPC error.
 		return nil, err
 	}
+	if err := vs.checkOptimisticProduction(ctx, req.Slot); err != nil {
+		return nil, err
+	}
 
 	v1alpha1req := &ethpbalpha.BlockRequest{
 		Slot:         req.Slot,
 		RandaoReveal: req.RandaoReveal,
-		Graffiti:     req.Graffiti,
+		Graffiti:     vs.graffiti(req.Graffiti),
 		SkipMevBoost: true, // Skip mev-boost and relayer network
 	}
 	v1alpha1resp, err := vs.V1Alpha1Server.GetBeaconBlock(ctx, v1alpha1req)
@@ -320,6 +330,12 @@ func (vs *Server) ProduceBlockV2(ctx context.Context, req *ethpbv1.ProduceBlockR
 		// We simply return err because it's already of a gRPC error type.
 		return nil, err
 	}
+	if err := vs.setSigningInfo(ctx, v1alpha1resp); err != nil {
+		return nil, err
+	}
+	if err := setNoStore(ctx); err != nil {
+		return nil, err
+	}
 	phase0Block, ok := v1alpha1resp.Block.(*ethpbalpha.GenericBeaconBlock_Phase0)
 	if ok {
 		block, err := migration.V1Alpha1ToV1Block(phase0Block.Phase0)
@@ -346,13 +362,6 @@ func (vs *Server) ProduceBlockV2(ctx context.Context, req *ethpbv1.ProduceBlockR
 			},
 		}, nil
 	}
-	optimistic, err := vs.OptimisticModeFetcher.IsOptimistic(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not determine if the node is a optimistic node: %v", err)
-	}
-	if optimistic {
-		return nil, status.Errorf(codes.Unavailable, "The node is currently optimistic and cannot serve validators")
-	}
 	_, ok = v1alpha1resp.Block.(*ethpbalpha.GenericBeaconBlock_BlindedBellatrix)
 	if ok {
 		return nil, status.Error(codes.Internal, "Prepared Bellatrix beacon block is blinded")
@@ -390,6 +399,97 @@ func (vs *Server) ProduceBlockV2(ctx context.Context, req *ethpbv1.ProduceBlockR
 	return nil, status.Error(codes.InvalidArgument, "Unsupported block type")
 }
 
+// checkOptimisticProduction refuses to produce a block for the given slot while the node is optimistic.
+// It runs before any block is built, so that every produce endpoint behaves the same. Blocks from before
+// Bellatrix have no execution payload and therefore don't depend on the execution chain the node is
+// optimistic about, so they are still produced.
+func (vs *Server) checkOptimisticProduction(ctx context.Context, slot primitives.Slot) error {
+	if slots.ToEpoch(slot) < params.BeaconConfig().BellatrixForkEpoch {
+		return nil
+	}
+	optimistic, err := vs.OptimisticModeFetcher.IsOptimistic(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not determine if the node is a optimistic node: %v", err)
+	}
+	if optimistic {
+		return status.Errorf(codes.Unavailable, "The node is currently optimistic and cannot serve validators")
+	}
+	return nil
+}
+
+// graffiti returns the graffiti of a block to be produced. A block requested without graffiti, or with
+// all-zero graffiti, is tagged with DefaultGraffiti instead.
+func (vs *Server) graffiti(requested []byte) []byte {
+	if len(requested) != 0 && !bytesutil.ZeroRoot(requested) {
+		return requested
+	}
+	if vs.DefaultGraffiti == [32]byte{} {
+		return requested
+	}
+	graffiti := vs.DefaultGraffiti
+	return graffiti[:]
+}
+
+// setSigningInfo sets the signing domain and signing root of a produced block as response headers when
+// they were requested with the Eth-Include-Signing-Info header, which saves clients the round trips to
+// fetch the fork and genesis validators root that are needed to compute them.
+func (vs *Server) setSigningInfo(ctx context.Context, blk *ethpbalpha.GenericBeaconBlock) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	if include := md.Get(api.IncludeSigningInfoHeader); len(include) == 0 || include[0] != "true" {
+		return nil
+	}
+	b, err := blocks.NewBeaconBlock(blk.Block)
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not get block: %v", err)
+	}
+	gvr := vs.ChainInfoFetcher.GenesisValidatorsRoot()
+	domain, root, err := blockSigningInfo(b, gvr[:])
+	if err != nil {
+		return status.Errorf(codes.Internal, "Could not compute signing info: %v", err)
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(
+		api.SigningDomainHeader, hexutil.Encode(domain),
+		api.SigningRootHeader, hexutil.Encode(root[:]),
+	)); err != nil {
+		return status.Errorf(codes.Internal, "Could not set signing info headers: %v", err)
+	}
+	return nil
+}
+
+// setNoStore forbids caching the response of block production, because every request must produce
+// a block for the current head. Direct calls, made outside of a server, have no response headers to set.
+func setNoStore(ctx context.Context) error {
+	if grpc.ServerTransportStreamFromContext(ctx) == nil {
+		return nil
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(api.CacheControlHeader, "no-store")); err != nil {
+		return status.Errorf(codes.Internal, "Could not set Cache-Control header: %v", err)
+	}
+	return nil
+}
+
+// blockSigningInfo computes the domain and root a proposer signs a block with, using the fork scheduled
+// at the block's epoch.
+func blockSigningInfo(b interfaces.ReadOnlyBeaconBlock, genesisValidatorsRoot []byte) ([]byte, [32]byte, error) {
+	epoch := slots.ToEpoch(b.Slot())
+	fork, err := forks.Fork(epoch)
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not get fork")
+	}
+	domain, err := signing.Domain(fork, epoch, params.BeaconConfig().DomainBeaconProposer, genesisValidatorsRoot)
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not get domain")
+	}
+	root, err := signing.ComputeSigningRoot(b, domain)
+	if err != nil {
+		return nil, [32]byte{}, errors.Wrap(err, "could not compute signing root")
+	}
+	return domain, root, nil
+}
+
 // ProduceBlockV2SSZ requests the beacon node to produce a valid unsigned beacon block, which can then be signed by a proposer and submitted.
 //
 // The produced block is in SSZ form.
@@ -407,11 +507,14 @@ func (vs *Server) ProduceBlockV2SSZ(ctx context.Context, req *ethpbv1.ProduceBlo
 		// We simply return the error because it's already a gRPC error.
 		return nil, err
 	}
+	if err := vs.checkOptimisticProduction(ctx, req.Slot); err != nil {
+		return nil, err
+	}
 
 	v1alpha1req := &ethpbalpha.BlockRequest{
 		Slot:         req.Slot,
 		RandaoReveal: req.RandaoReveal,
-		Graffiti:     req.Graffiti,
+		Graffiti:     vs.graffiti(req.Graffiti),
 		SkipMevBoost: true, // Skip mev-boost and relayer network
 	}
 	v1alpha1resp, err := vs.V1Alpha1Server.GetBeaconBlock(ctx, v1alpha1req)
@@ -419,6 +522,12 @@ func (vs *Server) ProduceBlockV2SSZ(ctx context.Context, req *ethpbv1.ProduceBlo
 		// We simply return err because it's already of a gRPC error type.
 		return nil, err
 	}
+	if err := vs.setSigningInfo(ctx, v1alpha1resp); err != nil {
+		return nil, err
+	}
+	if err := setNoStore(ctx); err != nil {
+		return nil, err
+	}
 	phase0Block, ok := v1alpha1resp.Block.(*ethpbalpha.GenericBeaconBlock_Phase0)
 	if ok {
 		block, err := migration.V1Alpha1ToV1Block(phase0Block.Phase0)
@@ -449,13 +558,6 @@ func (vs *Server) ProduceBlockV2SSZ(ctx context.Context, req *ethpbv1.ProduceBlo
 			Data:    sszBlock,
 		}, nil
 	}
-	optimistic, err := vs.OptimisticModeFetcher.IsOptimistic(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not determine if the node is a optimistic node: %v", err)
-	}
-	if optimistic {
-		return nil, status.Errorf(codes.Unavailable, "The node is currently optimistic and cannot serve validators")
-	}
 	_, ok = v1alpha1resp.Block.(*ethpbalpha.GenericBeaconBlock_BlindedBellatrix)
 	if ok {
 		return nil, status.Error(codes.Internal, "Prepared Bellatrix beacon block is blinded")
@@ -516,17 +618,26 @@ func (vs *Server) ProduceBlindedBlock(ctx context.Context, req *ethpbv1.ProduceB
 		// We simply return the error because it's already a gRPC error.
 		return nil, err
 	}
+	if err := vs.checkOptimisticProduction(ctx, req.Slot); err != nil {
+		return nil, err
+	}
 
 	v1alpha1req := &ethpbalpha.BlockRequest{
 		Slot:         req.Slot,
 		RandaoReveal: req.RandaoReveal,
-		Graffiti:     req.Graffiti,
+		Graffiti:     vs.graffiti(req.Graffiti),
 	}
 	v1alpha1resp, err := vs.V1Alpha1Server.GetBeaconBlock(ctx, v1alpha1req)
 	if err != nil {
 		// We simply return err because it's already of a gRPC error type.
 		return nil, err
 	}
+	if err := vs.setSigningInfo(ctx, v1alpha1resp); err != nil {
+		return nil, err
+	}
+	if err := setNoStore(ctx); err != nil {
+		return nil, err
+	}
 
 	phase0Block, ok := v1alpha1resp.Block.(*ethpbalpha.GenericBeaconBlock_Phase0)
 	if ok {
@@ -554,13 +665,6 @@ func (vs *Server) ProduceBlindedBlock(ctx context.Context, req *ethpbv1.ProduceB
 			},
 		}, nil
 	}
-	optimistic, err := vs.OptimisticModeFetcher.IsOptimistic(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not determine if the node is a optimistic node: %v", err)
-	}
-	if optimistic {
-		return nil, status.Errorf(codes.Unavailable, "The node is currently optimistic and cannot serve validators")
-	}
 	_, ok = v1alpha1resp.Block.(*ethpbalpha.GenericBeaconBlock_Bellatrix)
 	if ok {
 		return nil, status.Error(codes.Internal, "Prepared beacon block is not blinded")
@@ -615,17 +719,26 @@ func (vs *Server) ProduceBlindedBlockSSZ(ctx context.Context, req *ethpbv1.Produ
 		// We simply return the error because it's already a gRPC error.
 		return nil, err
 	}
+	if err := vs.checkOptimisticProduction(ctx, req.Slot); err != nil {
+		return nil, err
+	}
 
 	v1alpha1req := &ethpbalpha.BlockRequest{
 		Slot:         req.Slot,
 		RandaoReveal: req.RandaoReveal,
-		Graffiti:     req.Graffiti,
+		Graffiti:     vs.graffiti(req.Graffiti),
 	}
 	v1alpha1resp, err := vs.V1Alpha1Server.GetBeaconBlock(ctx, v1alpha1req)
 	if err != nil {
 		// We simply return err because it's already of a gRPC error type.
 		return nil, err
 	}
+	if err := vs.setSigningInfo(ctx, v1alpha1resp); err != nil {
+		return nil, err
+	}
+	if err := setNoStore(ctx); err != nil {
+		return nil, err
+	}
 
 	phase0Block, ok := v1alpha1resp.Block.(*ethpbalpha.GenericBeaconBlock_Phase0)
 	if ok {
@@ -657,13 +770,6 @@ func (vs *Server) ProduceBlindedBlockSSZ(ctx context.Context, req *ethpbv1.Produ
 			Data:    sszBlock,
 		}, nil
 	}
-	optimistic, err := vs.OptimisticModeFetcher.IsOptimistic(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Could not determine if the node is a optimistic node: %v", err)
-	}
-	if optimistic {
-		return nil, status.Errorf(codes.Unavailable, "The node is currently optimistic and cannot serve validators")
-	}
 	_, ok = v1alpha1resp.Block.(*ethpbalpha.GenericBeaconBlock_Bellatrix)
 	if ok {
 		return nil, status.Error(codes.Internal, "Prepared Bellatrix beacon block is not blinded")
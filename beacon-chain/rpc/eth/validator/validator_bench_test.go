@@ -0,0 +1,162 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	builderTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/builder/testing"
+	mockSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpbalpha "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/mock"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// Number and size of the transactions in the execution payload of benchmarked blocks,
+	// which is in the range of mainnet blocks.
+	benchmarkTxCount = 200
+	benchmarkTxSize  = 500
+)
+
+// benchmarkAttestations returns a full block's worth of attestations.
+func benchmarkAttestations() []*ethpbalpha.Attestation {
+	atts := make([]*ethpbalpha.Attestation, params.BeaconConfig().MaxAttestations)
+	for i := range atts {
+		atts[i] = util.HydrateAttestation(&ethpbalpha.Attestation{AggregationBits: make([]byte, 64)})
+	}
+	return atts
+}
+
+func benchmarkTransactions() [][]byte {
+	txs := make([][]byte, benchmarkTxCount)
+	for i := range txs {
+		txs[i] = make([]byte, benchmarkTxSize)
+	}
+	return txs
+}
+
+func benchmarkWithdrawals() []*enginev1.Withdrawal {
+	withdrawals := make([]*enginev1.Withdrawal, fieldparams.MaxWithdrawalsPerPayload)
+	for i := range withdrawals {
+		withdrawals[i] = &enginev1.Withdrawal{Address: make([]byte, fieldparams.FeeRecipientLength)}
+	}
+	return withdrawals
+}
+
+// benchmarkBlocks returns a block of each fork with a full set of attestations, and transactions
+// and withdrawals where the fork has them.
+func benchmarkBlocks() map[string]*ethpbalpha.GenericBeaconBlock {
+	phase0 := util.NewBeaconBlock().Block
+	phase0.Body.Attestations = benchmarkAttestations()
+	altair := util.NewBeaconBlockAltair().Block
+	altair.Body.Attestations = benchmarkAttestations()
+	bellatrix := util.NewBeaconBlockBellatrix().Block
+	bellatrix.Body.Attestations = benchmarkAttestations()
+	bellatrix.Body.ExecutionPayload.Transactions = benchmarkTransactions()
+	capella := util.NewBeaconBlockCapella().Block
+	capella.Body.Attestations = benchmarkAttestations()
+	capella.Body.ExecutionPayload.Transactions = benchmarkTransactions()
+	capella.Body.ExecutionPayload.Withdrawals = benchmarkWithdrawals()
+	return map[string]*ethpbalpha.GenericBeaconBlock{
+		"Phase 0":   {Block: &ethpbalpha.GenericBeaconBlock_Phase0{Phase0: phase0}},
+		"Altair":    {Block: &ethpbalpha.GenericBeaconBlock_Altair{Altair: altair}},
+		"Bellatrix": {Block: &ethpbalpha.GenericBeaconBlock_Bellatrix{Bellatrix: bellatrix}},
+		"Capella":   {Block: &ethpbalpha.GenericBeaconBlock_Capella{Capella: capella}},
+	}
+}
+
+// benchmarkBlindedBlocks returns a blinded block of each fork that has them, with a full set of attestations.
+func benchmarkBlindedBlocks() map[string]*ethpbalpha.GenericBeaconBlock {
+	bellatrix := util.NewBlindedBeaconBlockBellatrix().Block
+	bellatrix.Body.Attestations = benchmarkAttestations()
+	capella := util.NewBlindedBeaconBlockCapella().Block
+	capella.Body.Attestations = benchmarkAttestations()
+	return map[string]*ethpbalpha.GenericBeaconBlock{
+		"Bellatrix": {Block: &ethpbalpha.GenericBeaconBlock_BlindedBellatrix{BlindedBellatrix: bellatrix}},
+		"Capella":   {Block: &ethpbalpha.GenericBeaconBlock_BlindedCapella{BlindedCapella: capella}},
+	}
+}
+
+// benchmarkServer returns a server whose v1alpha1 server always produces the given block, so that only
+// the conversion and serialization of the block are measured.
+func benchmarkServer(b *testing.B, blk *ethpbalpha.GenericBeaconBlock) *Server {
+	ctrl := gomock.NewController(b)
+	v1alpha1Server := mock.NewMockBeaconNodeValidatorServer(ctrl)
+	v1alpha1Server.EXPECT().GetBeaconBlock(gomock.Any(), gomock.Any()).Return(blk, nil).AnyTimes()
+	return &Server{
+		V1Alpha1Server: v1alpha1Server,
+		SyncChecker:    &mockSync.Sync{IsSyncing: false},
+		BlockBuilder:   &builderTest.MockBuilderService{HasConfigured: true},
+	}
+}
+
+// BenchmarkProduceBlockV2 measures producing a block of each fork as JSON, the way the gateway serves it.
+func BenchmarkProduceBlockV2(b *testing.B) {
+	ctx := context.Background()
+	for name, blk := range benchmarkBlocks() {
+		server := benchmarkServer(b, blk)
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				resp, err := server.ProduceBlockV2(ctx, &ethpbv1.ProduceBlockRequest{})
+				require.NoError(b, err)
+				_, err = protojson.Marshal(resp)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+// BenchmarkProduceBlockV2SSZ measures producing a block of each fork as SSZ.
+func BenchmarkProduceBlockV2SSZ(b *testing.B) {
+	ctx := context.Background()
+	for name, blk := range benchmarkBlocks() {
+		server := benchmarkServer(b, blk)
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, err := server.ProduceBlockV2SSZ(ctx, &ethpbv1.ProduceBlockRequest{})
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+// BenchmarkProduceBlindedBlock measures producing a blinded block of each fork as JSON, the way the gateway serves it.
+func BenchmarkProduceBlindedBlock(b *testing.B) {
+	ctx := context.Background()
+	for name, blk := range benchmarkBlindedBlocks() {
+		server := benchmarkServer(b, blk)
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				resp, err := server.ProduceBlindedBlock(ctx, &ethpbv1.ProduceBlockRequest{})
+				require.NoError(b, err)
+				_, err = protojson.Marshal(resp)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+// BenchmarkProduceBlindedBlockSSZ measures producing a blinded block of each fork as SSZ.
+func BenchmarkProduceBlindedBlockSSZ(b *testing.B) {
+	ctx := context.Background()
+	for name, blk := range benchmarkBlindedBlocks() {
+		server := benchmarkServer(b, blk)
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, err := server.ProduceBlindedBlockSSZ(ctx, &ethpbv1.ProduceBlockRequest{})
+				require.NoError(b, err)
+			}
+		})
+	}
+}
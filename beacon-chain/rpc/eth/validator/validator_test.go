@@ -3,15 +3,20 @@ package validator
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/golang/mock/gomock"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prysmaticlabs/prysm/v4/api"
 	mockChain "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	builderTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/builder/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	dbutil "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/synccommittee"
@@ -34,6 +39,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -707,6 +714,12 @@ func TestSyncCommitteeDutiesLastValidEpoch(t *testing.T) {
 	})
 }
 
+func bellatrixStartSlot(t *testing.T) primitives.Slot {
+	slot, err := slots.EpochStart(params.BeaconConfig().BellatrixForkEpoch)
+	require.NoError(t, err)
+	return slot
+}
+
 func TestProduceBlockV2(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	ctx := context.Background()
@@ -807,7 +820,18 @@ func TestProduceBlockV2(t *testing.T) {
 		assert.ErrorContains(t, "Prepared Capella beacon block is blinded", err)
 	})
 	t.Run("optimistic", func(t *testing.T) {
-		blk := &ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_Bellatrix{Bellatrix: &ethpbalpha.BeaconBlockBellatrix{Slot: 123}}}
+		// No block is built while the node is optimistic, so the v1alpha1 server is never called.
+		server := &Server{
+			SyncChecker:           &mockSync.Sync{IsSyncing: false},
+			BlockBuilder:          &builderTest.MockBuilderService{HasConfigured: true},
+			OptimisticModeFetcher: &mockChain.ChainService{Optimistic: true},
+		}
+
+		_, err := server.ProduceBlockV2(ctx, &ethpbv1.ProduceBlockRequest{Slot: bellatrixStartSlot(t)})
+		require.ErrorContains(t, "The node is currently optimistic and cannot serve validators", err)
+	})
+	t.Run("optimistic before Bellatrix", func(t *testing.T) {
+		blk := &ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_Phase0{Phase0: &ethpbalpha.BeaconBlock{Slot: 123}}}
 		v1alpha1Server := mock.NewMockBeaconNodeValidatorServer(ctrl)
 		v1alpha1Server.EXPECT().GetBeaconBlock(gomock.Any(), gomock.Any()).Return(blk, nil)
 		server := &Server{
@@ -817,8 +841,8 @@ func TestProduceBlockV2(t *testing.T) {
 			OptimisticModeFetcher: &mockChain.ChainService{Optimistic: true},
 		}
 
-		_, err := server.ProduceBlockV2(ctx, &ethpbv1.ProduceBlockRequest{})
-		require.ErrorContains(t, "The node is currently optimistic and cannot serve validators", err)
+		_, err := server.ProduceBlockV2(ctx, &ethpbv1.ProduceBlockRequest{Slot: 123})
+		require.NoError(t, err)
 	})
 	t.Run("sync not ready", func(t *testing.T) {
 		chainService := &mockChain.ChainService{}
@@ -934,17 +958,14 @@ func TestProduceBlockV2SSZ(t *testing.T) {
 		assert.ErrorContains(t, "Prepared Capella beacon block is blinded", err)
 	})
 	t.Run("optimistic", func(t *testing.T) {
-		blk := &ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_Bellatrix{Bellatrix: &ethpbalpha.BeaconBlockBellatrix{Slot: 123}}}
-		v1alpha1Server := mock.NewMockBeaconNodeValidatorServer(ctrl)
-		v1alpha1Server.EXPECT().GetBeaconBlock(gomock.Any(), gomock.Any()).Return(blk, nil)
+		// No block is built while the node is optimistic, so the v1alpha1 server is never called.
 		server := &Server{
-			V1Alpha1Server:        v1alpha1Server,
 			SyncChecker:           &mockSync.Sync{IsSyncing: false},
 			BlockBuilder:          &builderTest.MockBuilderService{HasConfigured: true},
 			OptimisticModeFetcher: &mockChain.ChainService{Optimistic: true},
 		}
 
-		_, err := server.ProduceBlockV2SSZ(ctx, &ethpbv1.ProduceBlockRequest{})
+		_, err := server.ProduceBlockV2SSZ(ctx, &ethpbv1.ProduceBlockRequest{Slot: bellatrixStartSlot(t)})
 		require.ErrorContains(t, "The node is currently optimistic and cannot serve validators", err)
 	})
 	t.Run("sync not ready", func(t *testing.T) {
@@ -1067,7 +1088,18 @@ func TestProduceBlindedBlock(t *testing.T) {
 		assert.ErrorContains(t, "Prepared beacon block is not blinded", err)
 	})
 	t.Run("optimistic", func(t *testing.T) {
-		blk := &ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_BlindedBellatrix{BlindedBellatrix: &ethpbalpha.BlindedBeaconBlockBellatrix{Slot: 123}}}
+		// No block is built while the node is optimistic, so the v1alpha1 server is never called.
+		server := &Server{
+			SyncChecker:           &mockSync.Sync{IsSyncing: false},
+			BlockBuilder:          &builderTest.MockBuilderService{HasConfigured: true},
+			OptimisticModeFetcher: &mockChain.ChainService{Optimistic: true},
+		}
+
+		_, err := server.ProduceBlindedBlock(ctx, &ethpbv1.ProduceBlockRequest{Slot: bellatrixStartSlot(t)})
+		require.ErrorContains(t, "The node is currently optimistic and cannot serve validators", err)
+	})
+	t.Run("optimistic before Bellatrix", func(t *testing.T) {
+		blk := &ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_Phase0{Phase0: &ethpbalpha.BeaconBlock{Slot: 123}}}
 		v1alpha1Server := mock.NewMockBeaconNodeValidatorServer(ctrl)
 		v1alpha1Server.EXPECT().GetBeaconBlock(gomock.Any(), gomock.Any()).Return(blk, nil)
 		server := &Server{
@@ -1077,8 +1109,8 @@ func TestProduceBlindedBlock(t *testing.T) {
 			OptimisticModeFetcher: &mockChain.ChainService{Optimistic: true},
 		}
 
-		_, err := server.ProduceBlindedBlock(ctx, &ethpbv1.ProduceBlockRequest{})
-		require.ErrorContains(t, "The node is currently optimistic and cannot serve validators", err)
+		_, err := server.ProduceBlindedBlock(ctx, &ethpbv1.ProduceBlockRequest{Slot: 123})
+		require.NoError(t, err)
 	})
 	t.Run("builder not configured", func(t *testing.T) {
 		v1Server := &Server{
@@ -1208,17 +1240,14 @@ func TestProduceBlindedBlockSSZ(t *testing.T) {
 		assert.ErrorContains(t, "Prepared Capella beacon block is not blinded", err)
 	})
 	t.Run("optimistic", func(t *testing.T) {
-		blk := &ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_BlindedBellatrix{BlindedBellatrix: &ethpbalpha.BlindedBeaconBlockBellatrix{Slot: 123}}}
-		v1alpha1Server := mock.NewMockBeaconNodeValidatorServer(ctrl)
-		v1alpha1Server.EXPECT().GetBeaconBlock(gomock.Any(), gomock.Any()).Return(blk, nil)
+		// No block is built while the node is optimistic, so the v1alpha1 server is never called.
 		server := &Server{
-			V1Alpha1Server:        v1alpha1Server,
 			SyncChecker:           &mockSync.Sync{IsSyncing: false},
 			BlockBuilder:          &builderTest.MockBuilderService{HasConfigured: true},
 			OptimisticModeFetcher: &mockChain.ChainService{Optimistic: true},
 		}
 
-		_, err := server.ProduceBlindedBlockSSZ(ctx, &ethpbv1.ProduceBlockRequest{})
+		_, err := server.ProduceBlindedBlockSSZ(ctx, &ethpbv1.ProduceBlockRequest{Slot: bellatrixStartSlot(t)})
 		require.ErrorContains(t, "The node is currently optimistic and cannot serve validators", err)
 	})
 	t.Run("builder not configured", func(t *testing.T) {
@@ -1242,6 +1271,139 @@ func TestProduceBlindedBlockSSZ(t *testing.T) {
 	})
 }
 
+func TestProduceBlock_DefaultGraffiti(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	defaultGraffiti := bytesutil.ToBytes32([]byte("operator"))
+	explicitGraffiti := bytesutil.PadTo([]byte("validator"), 32)
+	blk := &ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_Phase0{Phase0: &ethpbalpha.BeaconBlock{Slot: 123}}}
+
+	tests := []struct {
+		name            string
+		defaultGraffiti [32]byte
+		requested       []byte
+		expected        []byte
+	}{
+		{name: "empty graffiti uses default", defaultGraffiti: defaultGraffiti, requested: nil, expected: defaultGraffiti[:]},
+		{name: "zero graffiti uses default", defaultGraffiti: defaultGraffiti, requested: make([]byte, 32), expected: defaultGraffiti[:]},
+		{name: "explicit graffiti overrides default", defaultGraffiti: defaultGraffiti, requested: explicitGraffiti, expected: explicitGraffiti},
+		{name: "no default", requested: nil, expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v1alpha1Server := mock.NewMockBeaconNodeValidatorServer(ctrl)
+			var graffiti [][]byte
+			v1alpha1Server.EXPECT().GetBeaconBlock(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, req *ethpbalpha.BlockRequest) (*ethpbalpha.GenericBeaconBlock, error) {
+					graffiti = append(graffiti, req.Graffiti)
+					return blk, nil
+				}).Times(2)
+			server := &Server{
+				V1Alpha1Server:  v1alpha1Server,
+				SyncChecker:     &mockSync.Sync{IsSyncing: false},
+				BlockBuilder:    &builderTest.MockBuilderService{HasConfigured: true},
+				DefaultGraffiti: tt.defaultGraffiti,
+			}
+
+			_, err := server.ProduceBlockV2(ctx, &ethpbv1.ProduceBlockRequest{Graffiti: tt.requested})
+			require.NoError(t, err)
+			_, err = server.ProduceBlindedBlock(ctx, &ethpbv1.ProduceBlockRequest{Graffiti: tt.requested})
+			require.NoError(t, err)
+			require.Equal(t, 2, len(graffiti))
+			for _, g := range graffiti {
+				assert.DeepEqual(t, tt.expected, g)
+			}
+		})
+	}
+}
+
+func TestProduceBlock_SigningInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	gvr := bytesutil.ToBytes32([]byte("genesis validators root"))
+	blk := util.NewBeaconBlock().Block
+	blk.Slot = 123
+	v1alpha1Server := mock.NewMockBeaconNodeValidatorServer(ctrl)
+	v1alpha1Server.EXPECT().GetBeaconBlock(gomock.Any(), gomock.Any()).Return(
+		&ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_Phase0{Phase0: blk}}, nil).AnyTimes()
+	server := &Server{
+		V1Alpha1Server:   v1alpha1Server,
+		SyncChecker:      &mockSync.Sync{IsSyncing: false},
+		BlockBuilder:     &builderTest.MockBuilderService{HasConfigured: true},
+		ChainInfoFetcher: &mockChain.ChainService{ValidatorsRoot: gvr},
+	}
+
+	t.Run("included", func(t *testing.T) {
+		stream := &runtime.ServerTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(api.IncludeSigningInfoHeader, "true"))
+		_, err := server.ProduceBlockV2(ctx, &ethpbv1.ProduceBlockRequest{Slot: blk.Slot})
+		require.NoError(t, err)
+
+		domain, err := signing.ComputeDomain(params.BeaconConfig().DomainBeaconProposer, params.BeaconConfig().GenesisForkVersion, gvr[:])
+		require.NoError(t, err)
+		root, err := signing.ComputeSigningRoot(blk, domain)
+		require.NoError(t, err)
+		d := stream.Header()[strings.ToLower(api.SigningDomainHeader)]
+		require.Equal(t, 1, len(d))
+		assert.Equal(t, hexutil.Encode(domain), d[0])
+		r := stream.Header()[strings.ToLower(api.SigningRootHeader)]
+		require.Equal(t, 1, len(r))
+		assert.Equal(t, hexutil.Encode(root[:]), r[0])
+	})
+	t.Run("not requested", func(t *testing.T) {
+		stream := &runtime.ServerTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+		_, err := server.ProduceBlockV2(ctx, &ethpbv1.ProduceBlockRequest{Slot: blk.Slot})
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(stream.Header()[strings.ToLower(api.SigningDomainHeader)]))
+		assert.Equal(t, 0, len(stream.Header()[strings.ToLower(api.SigningRootHeader)]))
+	})
+}
+
+func TestProduceBlock_NoStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	blk := util.NewBeaconBlock().Block
+	v1alpha1Server := mock.NewMockBeaconNodeValidatorServer(ctrl)
+	v1alpha1Server.EXPECT().GetBeaconBlock(gomock.Any(), gomock.Any()).Return(
+		&ethpbalpha.GenericBeaconBlock{Block: &ethpbalpha.GenericBeaconBlock_Phase0{Phase0: blk}}, nil).AnyTimes()
+	server := &Server{
+		V1Alpha1Server: v1alpha1Server,
+		SyncChecker:    &mockSync.Sync{IsSyncing: false},
+		BlockBuilder:   &builderTest.MockBuilderService{HasConfigured: true},
+	}
+	tests := []struct {
+		name    string
+		produce func(ctx context.Context) error
+	}{
+		{name: "ProduceBlockV2", produce: func(ctx context.Context) error {
+			_, err := server.ProduceBlockV2(ctx, &ethpbv1.ProduceBlockRequest{Slot: blk.Slot})
+			return err
+		}},
+		{name: "ProduceBlockV2SSZ", produce: func(ctx context.Context) error {
+			_, err := server.ProduceBlockV2SSZ(ctx, &ethpbv1.ProduceBlockRequest{Slot: blk.Slot})
+			return err
+		}},
+		{name: "ProduceBlindedBlock", produce: func(ctx context.Context) error {
+			_, err := server.ProduceBlindedBlock(ctx, &ethpbv1.ProduceBlockRequest{Slot: blk.Slot})
+			return err
+		}},
+		{name: "ProduceBlindedBlockSSZ", produce: func(ctx context.Context) error {
+			_, err := server.ProduceBlindedBlockSSZ(ctx, &ethpbv1.ProduceBlockRequest{Slot: blk.Slot})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &runtime.ServerTransportStream{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+			require.NoError(t, tt.produce(ctx))
+			h := stream.Header()[strings.ToLower(api.CacheControlHeader)]
+			require.Equal(t, 1, len(h))
+			assert.Equal(t, "no-store", h[0])
+		})
+	}
+}
+
 func TestProduceAttestationData(t *testing.T) {
 	block := util.NewBeaconBlock()
 	block.Block.Slot = 3*params.BeaconConfig().SlotsPerEpoch + 1
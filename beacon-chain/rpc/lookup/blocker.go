@@ -2,11 +2,15 @@ package lookup
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
@@ -29,6 +33,96 @@ func (e BlockIdParseError) Error() string {
 	return e.message
 }
 
+// BlockNotFoundError represents an error scenario where no block exists for a valid block ID.
+type BlockNotFoundError struct {
+	message string
+}
+
+// NewBlockNotFoundError creates a new error instance.
+func NewBlockNotFoundError(id string) BlockNotFoundError {
+	return BlockNotFoundError{
+		message: fmt.Sprintf("could not find block for block ID %s", id),
+	}
+}
+
+// Error returns the underlying error message.
+func (e BlockNotFoundError) Error() string {
+	return e.message
+}
+
+// BlockIdKind is the form of a block ID.
+type BlockIdKind int
+
+const (
+	// BlockIdHead identifies the canonical head block.
+	BlockIdHead BlockIdKind = iota
+	// BlockIdGenesis identifies the genesis block.
+	BlockIdGenesis
+	// BlockIdFinalized identifies the block of the finalized checkpoint.
+	BlockIdFinalized
+	// BlockIdJustified identifies the block of the current justified checkpoint.
+	BlockIdJustified
+	// BlockIdSlot identifies the canonical block at a slot.
+	BlockIdSlot
+	// BlockIdRoot identifies a block by its root.
+	BlockIdRoot
+)
+
+var blockIdKeywords = map[string]BlockIdKind{
+	"head":      BlockIdHead,
+	"genesis":   BlockIdGenesis,
+	"finalized": BlockIdFinalized,
+	"justified": BlockIdJustified,
+}
+
+// BlockId is a parsed block ID. Slot is only set for BlockIdSlot, and Root only for BlockIdRoot.
+type BlockId struct {
+	Kind BlockIdKind
+	Slot primitives.Slot
+	Root [32]byte
+	raw  string
+}
+
+// ParseBlockId parses a block ID as it appears in the URL of a read endpoint. The ID can be one of
+// the keywords "head", "genesis", "finalized" and "justified", a slot or a 0x-prefixed hex encoded
+// block root. A *BlockIdParseError is returned for a malformed ID.
+func ParseBlockId(id string) (BlockId, error) {
+	if kind, ok := blockIdKeywords[id]; ok {
+		return BlockId{Kind: kind, raw: id}, nil
+	}
+	if strings.HasPrefix(id, "0x") {
+		root, err := hexutil.Decode(id)
+		if err != nil {
+			e := NewBlockIdParseError(err)
+			return BlockId{}, &e
+		}
+		if len(root) != fieldparams.RootLength {
+			e := NewBlockIdParseError(fmt.Errorf("root has %d bytes, expected %d", len(root), fieldparams.RootLength))
+			return BlockId{}, &e
+		}
+		return BlockId{Kind: BlockIdRoot, Root: bytesutil.ToBytes32(root), raw: id}, nil
+	}
+	slot, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		e := NewBlockIdParseError(err)
+		return BlockId{}, &e
+	}
+	return BlockId{Kind: BlockIdSlot, Slot: primitives.Slot(slot), raw: id}, nil
+}
+
+// Bytes returns the block ID in the form accepted by Blocker.Block.
+func (b BlockId) Bytes() []byte {
+	if b.Kind == BlockIdRoot {
+		return b.Root[:]
+	}
+	return []byte(b.raw)
+}
+
+// String returns the block ID as it was parsed.
+func (b BlockId) String() string {
+	return b.raw
+}
+
 // Blocker is responsible for retrieving blocks.
 type Blocker interface {
 	Block(ctx context.Context, id []byte) (interfaces.ReadOnlySignedBeaconBlock, error)
@@ -63,6 +157,15 @@ func (p *BeaconDbBlocker) Block(ctx context.Context, id []byte) (interfaces.Read
 		if err != nil {
 			return nil, errors.New("could not get finalized block from db")
 		}
+	case "justified":
+		justified := p.ChainInfoFetcher.CurrentJustifiedCheckpt()
+		if justified == nil {
+			return nil, nil
+		}
+		blk, err = p.BeaconDB.Block(ctx, bytesutil.ToBytes32(justified.Root))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get justified block from db")
+		}
 	case "genesis":
 		blk, err = p.BeaconDB.GenesisBlock(ctx)
 		if err != nil {
@@ -6,6 +6,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	dbtesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
@@ -49,11 +51,12 @@ func TestGetBlock(t *testing.T) {
 	fetcher := &BeaconDbBlocker{
 		BeaconDB: beaconDB,
 		ChainInfoFetcher: &mock.ChainService{
-			DB:                  beaconDB,
-			Block:               wsb,
-			Root:                headBlock.BlockRoot,
-			FinalizedCheckPoint: &ethpbalpha.Checkpoint{Root: blkContainers[64].BlockRoot},
-			CanonicalRoots:      canonicalRoots,
+			DB:                         beaconDB,
+			Block:                      wsb,
+			Root:                       headBlock.BlockRoot,
+			FinalizedCheckPoint:        &ethpbalpha.Checkpoint{Root: blkContainers[64].BlockRoot},
+			CurrentJustifiedCheckPoint: &ethpbalpha.Checkpoint{Root: blkContainers[96].BlockRoot},
+			CanonicalRoots:             canonicalRoots,
 		},
 	}
 
@@ -96,6 +99,11 @@ func TestGetBlock(t *testing.T) {
 			blockID: []byte("finalized"),
 			want:    blkContainers[64].Block.(*ethpbalpha.BeaconBlockContainer_Phase0Block).Phase0Block,
 		},
+		{
+			name:    "justified",
+			blockID: []byte("justified"),
+			want:    blkContainers[96].Block.(*ethpbalpha.BeaconBlockContainer_Phase0Block).Phase0Block,
+		},
 		{
 			name:    "genesis",
 			blockID: []byte("genesis"),
@@ -141,4 +149,57 @@ func TestGetBlock(t *testing.T) {
 			}
 		})
 	}
+	t.Run("justified before finality", func(t *testing.T) {
+		fetcher := &BeaconDbBlocker{
+			BeaconDB: beaconDB,
+			ChainInfoFetcher: &mock.ChainService{
+				CurrentJustifiedCheckPoint: &ethpbalpha.Checkpoint{Root: make([]byte, 32)},
+			},
+		}
+		result, err := fetcher.Block(ctx, []byte("justified"))
+		require.NoError(t, err)
+		assert.Equal(t, nil, result)
+	})
+}
+
+func TestParseBlockId(t *testing.T) {
+	root := bytesutil.PadTo([]byte("root"), 32)
+	tests := []struct {
+		id   string
+		want BlockId
+	}{
+		{id: "head", want: BlockId{Kind: BlockIdHead, raw: "head"}},
+		{id: "genesis", want: BlockId{Kind: BlockIdGenesis, raw: "genesis"}},
+		{id: "finalized", want: BlockId{Kind: BlockIdFinalized, raw: "finalized"}},
+		{id: "justified", want: BlockId{Kind: BlockIdJustified, raw: "justified"}},
+		{id: "0", want: BlockId{Kind: BlockIdSlot, Slot: 0, raw: "0"}},
+		{id: "123", want: BlockId{Kind: BlockIdSlot, Slot: 123, raw: "123"}},
+		{id: hexutil.Encode(root), want: BlockId{Kind: BlockIdRoot, Root: bytesutil.ToBytes32(root), raw: hexutil.Encode(root)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, err := ParseBlockId(tt.id)
+			require.NoError(t, err)
+			assert.DeepEqual(t, tt.want, got)
+			assert.Equal(t, tt.id, got.String())
+		})
+	}
+	t.Run("bytes", func(t *testing.T) {
+		id, err := ParseBlockId(hexutil.Encode(root))
+		require.NoError(t, err)
+		assert.DeepEqual(t, root, id.Bytes())
+		id, err = ParseBlockId("123")
+		require.NoError(t, err)
+		assert.DeepEqual(t, []byte("123"), id.Bytes())
+		id, err = ParseBlockId("head")
+		require.NoError(t, err)
+		assert.DeepEqual(t, []byte("head"), id.Bytes())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for _, id := range []string{"", "foo", "Head", "-1", "1.5", "18446744073709551616", "0xzz", "0x0102"} {
+			_, err := ParseBlockId(id)
+			var parseErr *BlockIdParseError
+			assert.Equal(t, true, errors.As(err, &parseErr), "expected parse error for block ID %s", id)
+		}
+	})
 }
@@ -32,6 +32,25 @@ var builderGetPayloadMissCount = promauto.NewCounter(prometheus.CounterOpts{
 	Help: "The number of get payload misses for validator requests to builder",
 })
 
+// builderExcludedCount tracks the number of bids discarded because their builder is excluded from block production.
+var builderExcludedCount = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "builder_excluded_bid_count",
+	Help: "The number of builder bids discarded because the builder is excluded from block production",
+})
+
+var (
+	// builderBlockChosenCount tracks the number of produced blocks that use the builder's execution payload.
+	builderBlockChosenCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "builder_block_chosen",
+		Help: "The number of produced blocks using the execution payload of the builder",
+	}, []string{"fork"})
+	// localBlockChosenCount tracks the number of produced blocks that use the local execution payload.
+	localBlockChosenCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "local_block_chosen",
+		Help: "The number of produced blocks using the execution payload of the local execution client",
+	}, []string{"fork"})
+)
+
 // emptyTransactionsRoot represents the returned value of ssz.TransactionsRoot([][]byte{}) and
 // can be used as a constant to avoid recomputing this value in every call.
 var emptyTransactionsRoot = [32]byte{127, 254, 36, 30, 166, 1, 135, 253, 176, 24, 123, 250, 34, 222, 53, 209, 249, 190, 215, 171, 6, 29, 148, 1, 253, 71, 227, 74, 84, 251, 237, 225}
@@ -41,7 +60,7 @@ var emptyTransactionsRoot = [32]byte{127, 254, 36, 30, 166, 1, 135, 253, 176, 24
 const blockBuilderTimeout = 1 * time.Second
 
 // Sets the execution data for the block. Execution data can come from local EL client or remote builder depends on validator registration and circuit breaker conditions.
-func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, localPayload, builderPayload interfaces.ExecutionData) error {
+func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, localPayload, builderPayload interfaces.ExecutionData) (err error) {
 	_, span := trace.StartSpan(ctx, "ProposerServer.setExecutionData")
 	defer span.End()
 
@@ -53,6 +72,11 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 	if localPayload == nil {
 		return errors.New("local payload is nil")
 	}
+	defer func() {
+		if err == nil {
+			recordExecutionDataChoice(blk)
+		}
+	}()
 
 	// Use local payload if builder payload is nil.
 	if builderPayload == nil {
@@ -121,6 +145,30 @@ func setExecutionData(ctx context.Context, blk interfaces.SignedBeaconBlock, loc
 	}
 }
 
+// recordExecutionDataChoice updates the metrics tracking whether the builder's or the local
+// execution payload ended up in the block, and logs the value of the chosen payload.
+func recordExecutionDataChoice(blk interfaces.SignedBeaconBlock) {
+	fork := version.String(blk.Version())
+	source := "local"
+	if blk.IsBlinded() {
+		source = "builder"
+		builderBlockChosenCount.WithLabelValues(fork).Inc()
+	} else {
+		localBlockChosenCount.WithLabelValues(fork).Inc()
+	}
+	fields := logrus.Fields{
+		"slot":   blk.Block().Slot(),
+		"fork":   fork,
+		"source": source,
+	}
+	if payload, err := blk.Block().Body().Execution(); err == nil {
+		if value, err := payload.ValueInGwei(); err == nil {
+			fields["payloadGweiValue"] = value
+		}
+	}
+	log.WithFields(fields).Debug("Proposer: chose execution payload")
+}
+
 // This function retrieves the payload header given the slot number and the validator index.
 // It's a no-op if the latest head block is not versioned bellatrix.
 func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitives.Slot, idx primitives.ValidatorIndex) (interfaces.ExecutionData, error) {
@@ -174,6 +222,17 @@ func (vs *Server) getPayloadHeaderFromBuilder(ctx context.Context, slot primitiv
 	if bid.IsNil() {
 		return nil, errors.New("builder returned nil bid")
 	}
+	// Bids from excluded builders are discarded, which makes the proposer fall back to the local payload.
+	// The exclusion is the operator's choice, so it isn't reported as a builder failure.
+	if vs.ExcludedBuilders[bytesutil.ToBytes48(bid.Pubkey())] {
+		builderExcludedCount.Inc()
+		log.WithFields(logrus.Fields{
+			"builderPubKey": fmt.Sprintf("%#x", bid.Pubkey()),
+			"slot":          slot,
+			"validator":     idx,
+		}).Info("Discarded bid of excluded builder")
+		return nil, nil
+	}
 
 	v := bytesutil.LittleEndianBytesToBigInt(bid.Value())
 	if v.String() == "0" {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
 	blockchainTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	builderTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/builder/testing"
@@ -27,6 +28,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
 	v1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
@@ -208,6 +210,26 @@ func TestServer_setExecutionData(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, uint64(2), e.BlockNumber()) // Builder block
 	})
+	t.Run("Builder configured. Builder Block has higher value. Builder is excluded. Use local block", func(t *testing.T) {
+		pk := vs.BlockBuilder.(*builderTest.MockBuilderService).BidCapella.Message.Pubkey
+		vs.ExcludedBuilders = map[[fieldparams.BLSPubkeyLength]byte]bool{bytesutil.ToBytes48(pk): true}
+		defer func() {
+			vs.ExcludedBuilders = nil
+		}()
+
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockCapella())
+		require.NoError(t, err)
+		b := blk.Block()
+		localPayload, err := vs.getLocalPayload(ctx, b, capellaTransitionState)
+		require.NoError(t, err)
+		builderPayload, err := vs.getBuilderPayload(ctx, b.Slot(), b.ProposerIndex())
+		require.NoError(t, err)
+		require.Equal(t, nil, builderPayload)
+		require.NoError(t, setExecutionData(context.Background(), blk, localPayload, builderPayload))
+		e, err := blk.Block().Body().Execution()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), e.BlockNumber()) // Local block
+	})
 	t.Run("Builder configured. Local block has higher value", func(t *testing.T) {
 		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockCapella())
 		require.NoError(t, err)
@@ -264,6 +286,41 @@ func TestServer_setExecutionData(t *testing.T) {
 		require.Equal(t, uint64(4), e.BlockNumber()) // Local block
 	})
 }
+
+func TestSetExecutionData_PayloadChoiceMetrics(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.BellatrixForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	localPayload, err := blocks.WrappedExecutionPayload(&v1.ExecutionPayload{BlockNumber: 1})
+	require.NoError(t, err)
+	builderPayload, err := blocks.WrappedExecutionPayloadHeader(&v1.ExecutionPayloadHeader{BlockNumber: 2})
+	require.NoError(t, err)
+	fork := version.String(version.Bellatrix)
+
+	t.Run("local", func(t *testing.T) {
+		local := promtestutil.ToFloat64(localBlockChosenCount.WithLabelValues(fork))
+		builder := promtestutil.ToFloat64(builderBlockChosenCount.WithLabelValues(fork))
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
+		require.NoError(t, err)
+		require.NoError(t, setExecutionData(context.Background(), blk, localPayload, nil))
+		require.Equal(t, false, blk.IsBlinded())
+		require.Equal(t, local+1, promtestutil.ToFloat64(localBlockChosenCount.WithLabelValues(fork)))
+		require.Equal(t, builder, promtestutil.ToFloat64(builderBlockChosenCount.WithLabelValues(fork)))
+	})
+	t.Run("builder", func(t *testing.T) {
+		local := promtestutil.ToFloat64(localBlockChosenCount.WithLabelValues(fork))
+		builder := promtestutil.ToFloat64(builderBlockChosenCount.WithLabelValues(fork))
+		blk, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
+		require.NoError(t, err)
+		require.NoError(t, setExecutionData(context.Background(), blk, localPayload, builderPayload))
+		require.Equal(t, true, blk.IsBlinded())
+		require.Equal(t, local, promtestutil.ToFloat64(localBlockChosenCount.WithLabelValues(fork)))
+		require.Equal(t, builder+1, promtestutil.ToFloat64(builderBlockChosenCount.WithLabelValues(fork)))
+	})
+}
+
 func TestServer_getPayloadHeader(t *testing.T) {
 	genesis := time.Now().Add(-time.Duration(params.BeaconConfig().SlotsPerEpoch) * time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
 	params.SetupTestConfigCleanup(t)
@@ -350,6 +407,7 @@ func TestServer_getPayloadHeader(t *testing.T) {
 		head                  interfaces.ReadOnlySignedBeaconBlock
 		mock                  *builderTest.MockBuilderService
 		fetcher               *blockchainTest.ChainService
+		excluded              map[[fieldparams.BLSPubkeyLength]byte]bool
 		err                   string
 		returnedHeader        *v1.ExecutionPayloadHeader
 		returnedHeaderCapella *v1.ExecutionPayloadHeaderCapella
@@ -441,6 +499,21 @@ func TestServer_getPayloadHeader(t *testing.T) {
 			},
 			returnedHeader: bid.Header,
 		},
+		{
+			name: "excluded builder",
+			mock: &builderTest.MockBuilderService{
+				Bid: sBid,
+			},
+			fetcher: &blockchainTest.ChainService{
+				Block: func() interfaces.ReadOnlySignedBeaconBlock {
+					wb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
+					require.NoError(t, err)
+					wb.SetSlot(primitives.Slot(params.BeaconConfig().BellatrixForkEpoch) * params.BeaconConfig().SlotsPerEpoch)
+					return wb
+				}(),
+			},
+			excluded: map[[fieldparams.BLSPubkeyLength]byte]bool{bytesutil.ToBytes48(sk.PublicKey().Marshal()): true},
+		},
 		{
 			name: "wrong bid version",
 			mock: &builderTest.MockBuilderService{
@@ -474,16 +547,21 @@ func TestServer_getPayloadHeader(t *testing.T) {
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			vs := &Server{BlockBuilder: tc.mock, HeadFetcher: tc.fetcher, TimeFetcher: &blockchainTest.ChainService{
+			vs := &Server{BlockBuilder: tc.mock, HeadFetcher: tc.fetcher, ExcludedBuilders: tc.excluded, TimeFetcher: &blockchainTest.ChainService{
 				Genesis: genesis,
 			}}
 			hb, err := vs.HeadFetcher.HeadBlock(context.Background())
 			require.NoError(t, err)
+			excludedCount := promtestutil.ToFloat64(builderExcludedCount)
 			h, err := vs.getPayloadHeaderFromBuilder(context.Background(), hb.Block().Slot(), 0)
 			if tc.err != "" {
 				require.ErrorContains(t, tc.err, err)
 			} else {
 				require.NoError(t, err)
+				if tc.excluded != nil {
+					require.Equal(t, nil, h)
+					require.Equal(t, excludedCount+1, promtestutil.ToFloat64(builderExcludedCount))
+				}
 				if tc.returnedHeader != nil {
 					want, err := blocks.WrappedExecutionPayloadHeader(tc.returnedHeader)
 					require.NoError(t, err)
@@ -1,20 +1,27 @@
 package validator
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/builder"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	consensus_types "github.com/prysmaticlabs/prysm/v4/consensus-types"
 	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/proto"
 )
 
+// ErrPayloadMismatch is returned when the payload revealed by the builder doesn't match
+// the execution payload header of the blinded block, which points at a misbehaving relay.
+var ErrPayloadMismatch = errors.New("builder payload does not match the blinded block's payload header")
+
 type unblinder struct {
 	b       interfaces.SignedBeaconBlock
 	builder builder.BlockBuilder
@@ -64,6 +71,14 @@ func (u *unblinder) unblindBuilderBlock(ctx context.Context) (interfaces.SignedB
 	if err != nil {
 		return nil, errors.Wrap(err, "could not submit blinded block")
 	}
+	if err = checkTransactionsRoot(h, payload); err != nil {
+		return nil, err
+	}
+	if u.b.Version() >= version.Capella {
+		if err = checkWithdrawalsRoot(h, payload); err != nil {
+			return nil, err
+		}
+	}
 	headerRoot, err := h.HashTreeRoot()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get header root")
@@ -107,6 +122,46 @@ func (u *unblinder) unblindBuilderBlock(ctx context.Context) (interfaces.SignedB
 	return wb, nil
 }
 
+// checkTransactionsRoot verifies that the transactions of the payload hash to the transactions root of the header.
+func checkTransactionsRoot(header, payload interfaces.ExecutionData) error {
+	headerRoot, err := header.TransactionsRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not get header transactions root")
+	}
+	txs, err := payload.Transactions()
+	if err != nil {
+		return errors.Wrap(err, "could not get payload transactions")
+	}
+	payloadRoot, err := ssz.TransactionsRoot(txs)
+	if err != nil {
+		return errors.Wrap(err, "could not compute payload transactions root")
+	}
+	if !bytes.Equal(headerRoot, payloadRoot[:]) {
+		return errors.Wrapf(ErrPayloadMismatch, "transactions root %#x of %d transactions does not match header transactions root %#x", payloadRoot, len(txs), headerRoot)
+	}
+	return nil
+}
+
+// checkWithdrawalsRoot verifies that the withdrawals of the payload hash to the withdrawals root of the header.
+func checkWithdrawalsRoot(header, payload interfaces.ExecutionData) error {
+	headerRoot, err := header.WithdrawalsRoot()
+	if err != nil {
+		return errors.Wrap(err, "could not get header withdrawals root")
+	}
+	withdrawals, err := payload.Withdrawals()
+	if err != nil {
+		return errors.Wrap(err, "could not get payload withdrawals")
+	}
+	payloadRoot, err := ssz.WithdrawalSliceRoot(withdrawals, fieldparams.MaxWithdrawalsPerPayload)
+	if err != nil {
+		return errors.Wrap(err, "could not compute payload withdrawals root")
+	}
+	if !bytes.Equal(headerRoot, payloadRoot[:]) {
+		return errors.Wrapf(ErrPayloadMismatch, "withdrawals root %#x does not match header withdrawals root %#x", payloadRoot, headerRoot)
+	}
+	return nil
+}
+
 func copyBlockData(src interfaces.SignedBeaconBlock, dst interfaces.SignedBeaconBlock) error {
 	agg, err := src.Block().Body().SyncAggregate()
 	if err != nil {
@@ -21,6 +21,17 @@ func Test_unblindBuilderBlock(t *testing.T) {
 	p.GasLimit = 123
 	pCapella := emptyPayloadCapella()
 	pCapella.GasLimit = 123
+	pTxs := emptyPayload()
+	pTxs.Transactions = [][]byte{{'a'}}
+	pCapellaTxs := emptyPayloadCapella()
+	pCapellaTxs.Transactions = [][]byte{{'a'}}
+	pCapellaWithdrawals := emptyPayloadCapella()
+	pCapellaWithdrawals.Withdrawals = []*v1.Withdrawal{{
+		Index:          1,
+		ValidatorIndex: 2,
+		Address:        make([]byte, fieldparams.FeeRecipientLength),
+		Amount:         3,
+	}}
 
 	tests := []struct {
 		name        string
@@ -113,6 +124,9 @@ func Test_unblindBuilderBlock(t *testing.T) {
 				b := util.NewBlindedBeaconBlockBellatrix()
 				b.Block.Slot = 1
 				b.Block.ProposerIndex = 2
+				txRoot, err := ssz.TransactionsRoot([][]byte{})
+				require.NoError(t, err)
+				b.Block.Body.ExecutionPayloadHeader.TransactionsRoot = txRoot[:]
 				wb, err := blocks.NewSignedBeaconBlock(b)
 				require.NoError(t, err)
 				return wb
@@ -132,6 +146,66 @@ func Test_unblindBuilderBlock(t *testing.T) {
 			}(),
 			err: "header and payload root do not match",
 		},
+		{
+			name: "transactions root mismatch Bellatrix",
+			blk: func() interfaces.SignedBeaconBlock {
+				b := util.NewBlindedBeaconBlockBellatrix()
+				b.Block.Slot = 1
+				b.Block.ProposerIndex = 2
+				txRoot, err := ssz.TransactionsRoot([][]byte{})
+				require.NoError(t, err)
+				b.Block.Body.ExecutionPayloadHeader.TransactionsRoot = txRoot[:]
+				wb, err := blocks.NewSignedBeaconBlock(b)
+				require.NoError(t, err)
+				return wb
+			}(),
+			mock: &builderTest.MockBuilderService{
+				HasConfigured: true,
+				Payload:       pTxs,
+			},
+			err: "transactions root",
+		},
+		{
+			name: "transactions root mismatch Capella",
+			blk: func() interfaces.SignedBeaconBlock {
+				b := util.NewBlindedBeaconBlockCapella()
+				b.Block.Slot = 1
+				b.Block.ProposerIndex = 2
+				txRoot, err := ssz.TransactionsRoot([][]byte{})
+				require.NoError(t, err)
+				b.Block.Body.ExecutionPayloadHeader.TransactionsRoot = txRoot[:]
+				wb, err := blocks.NewSignedBeaconBlock(b)
+				require.NoError(t, err)
+				return wb
+			}(),
+			mock: &builderTest.MockBuilderService{
+				HasConfigured:  true,
+				PayloadCapella: pCapellaTxs,
+			},
+			err: "transactions root",
+		},
+		{
+			name: "withdrawals root mismatch",
+			blk: func() interfaces.SignedBeaconBlock {
+				b := util.NewBlindedBeaconBlockCapella()
+				b.Block.Slot = 1
+				b.Block.ProposerIndex = 2
+				txRoot, err := ssz.TransactionsRoot([][]byte{})
+				require.NoError(t, err)
+				b.Block.Body.ExecutionPayloadHeader.TransactionsRoot = txRoot[:]
+				withdrawalsRoot, err := ssz.WithdrawalSliceRoot([]*v1.Withdrawal{}, fieldparams.MaxWithdrawalsPerPayload)
+				require.NoError(t, err)
+				b.Block.Body.ExecutionPayloadHeader.WithdrawalsRoot = withdrawalsRoot[:]
+				wb, err := blocks.NewSignedBeaconBlock(b)
+				require.NoError(t, err)
+				return wb
+			}(),
+			mock: &builderTest.MockBuilderService{
+				HasConfigured:  true,
+				PayloadCapella: pCapellaWithdrawals,
+			},
+			err: "withdrawals root",
+		},
 		{
 			name: "can get payload Bellatrix",
 			blk: func() interfaces.SignedBeaconBlock {
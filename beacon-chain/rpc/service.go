@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -50,6 +51,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	chainSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/v4/config/features"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/io/logs"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
@@ -72,6 +74,8 @@ type Service struct {
 	cancel               context.CancelFunc
 	listener             net.Listener
 	grpcServer           *grpc.Server
+	beaconServerV1       *beacon.Server
+	validatorServerV1    *validator.Server
 	incomingAttestation  chan *ethpbv1alpha1.Attestation
 	credentialError      error
 	connectedRPCClients  map[net.Addr]bool
@@ -125,6 +129,15 @@ type Config struct {
 	ProposerIdsCache              *cache.ProposerPayloadIDsCache
 	OptimisticModeFetcher         blockchain.OptimisticModeFetcher
 	BlockBuilder                  builder.BlockBuilder
+	ExcludedBuilders              map[[fieldparams.BLSPubkeyLength]byte]bool
+	StrictAmountValidation        bool
+	AcceptedForks                 map[int]bool
+	PublishRequestTimeout         time.Duration
+	LenientJSONDecoding           bool
+	MaxConsensusValidations       int
+	SSZOnly                       bool
+	DefaultGraffiti               [32]byte
+	ClockDisparityTolerance       time.Duration
 	Router                        *mux.Router
 	ClockWaiter                   startup.ClockWaiter
 }
@@ -274,6 +287,7 @@ func (s *Service) Start() {
 		BeaconDB:               s.cfg.BeaconDB,
 		ProposerSlotIndexCache: s.cfg.ProposerIdsCache,
 		BlockBuilder:           s.cfg.BlockBuilder,
+		ExcludedBuilders:       s.cfg.ExcludedBuilders,
 		BLSChangesPool:         s.cfg.BLSChangesPool,
 		ClockWaiter:            s.cfg.ClockWaiter,
 		CoreService:            coreService,
@@ -295,7 +309,9 @@ func (s *Service) Start() {
 		BlockBuilder:           s.cfg.BlockBuilder,
 		OperationNotifier:      s.cfg.OperationNotifier,
 		CoreService:            coreService,
+		DefaultGraffiti:        s.cfg.DefaultGraffiti,
 	}
+	s.validatorServerV1 = validatorServerV1
 
 	s.cfg.Router.HandleFunc("/eth/v1/validator/aggregate_attestation", validatorServerV1.GetAggregateAttestation).Methods(http.MethodGet)
 	s.cfg.Router.HandleFunc("/eth/v1/validator/contribution_and_proofs", validatorServerV1.SubmitContributionAndProofs).Methods(http.MethodPost)
@@ -373,30 +389,38 @@ func (s *Service) Start() {
 		CoreService:                 coreService,
 	}
 	beaconChainServerV1 := &beacon.Server{
-		CanonicalHistory:              ch,
-		BeaconDB:                      s.cfg.BeaconDB,
-		AttestationsPool:              s.cfg.AttestationsPool,
-		SlashingsPool:                 s.cfg.SlashingsPool,
-		ChainInfoFetcher:              s.cfg.ChainInfoFetcher,
-		GenesisTimeFetcher:            s.cfg.GenesisTimeFetcher,
-		BlockNotifier:                 s.cfg.BlockNotifier,
-		OperationNotifier:             s.cfg.OperationNotifier,
-		Broadcaster:                   s.cfg.Broadcaster,
-		BlockReceiver:                 s.cfg.BlockReceiver,
-		StateGenService:               s.cfg.StateGen,
-		Stater:                        stater,
-		Blocker:                       blocker,
-		OptimisticModeFetcher:         s.cfg.OptimisticModeFetcher,
-		HeadFetcher:                   s.cfg.HeadFetcher,
-		TimeFetcher:                   s.cfg.GenesisTimeFetcher,
-		VoluntaryExitsPool:            s.cfg.ExitPool,
-		V1Alpha1ValidatorServer:       validatorServer,
-		SyncChecker:                   s.cfg.SyncService,
-		ExecutionPayloadReconstructor: s.cfg.ExecutionPayloadReconstructor,
-		BLSChangesPool:                s.cfg.BLSChangesPool,
-		FinalizationFetcher:           s.cfg.FinalizationFetcher,
-		ForkchoiceFetcher:             s.cfg.ForkchoiceFetcher,
+		CanonicalHistory:                  ch,
+		BeaconDB:                          s.cfg.BeaconDB,
+		AttestationsPool:                  s.cfg.AttestationsPool,
+		SlashingsPool:                     s.cfg.SlashingsPool,
+		ChainInfoFetcher:                  s.cfg.ChainInfoFetcher,
+		GenesisTimeFetcher:                s.cfg.GenesisTimeFetcher,
+		BlockNotifier:                     s.cfg.BlockNotifier,
+		OperationNotifier:                 s.cfg.OperationNotifier,
+		Broadcaster:                       s.cfg.Broadcaster,
+		BlockReceiver:                     s.cfg.BlockReceiver,
+		StateGenService:                   s.cfg.StateGen,
+		Stater:                            stater,
+		Blocker:                           blocker,
+		OptimisticModeFetcher:             s.cfg.OptimisticModeFetcher,
+		HeadFetcher:                       s.cfg.HeadFetcher,
+		TimeFetcher:                       s.cfg.GenesisTimeFetcher,
+		VoluntaryExitsPool:                s.cfg.ExitPool,
+		V1Alpha1ValidatorServer:           validatorServer,
+		SyncChecker:                       s.cfg.SyncService,
+		ExecutionPayloadReconstructor:     s.cfg.ExecutionPayloadReconstructor,
+		BLSChangesPool:                    s.cfg.BLSChangesPool,
+		FinalizationFetcher:               s.cfg.FinalizationFetcher,
+		ForkchoiceFetcher:                 s.cfg.ForkchoiceFetcher,
+		StrictAmountValidation:            s.cfg.StrictAmountValidation,
+		AcceptedForks:                     s.cfg.AcceptedForks,
+		RequestTimeout:                    s.cfg.PublishRequestTimeout,
+		LenientJSONDecoding:               s.cfg.LenientJSONDecoding,
+		MaxConcurrentConsensusValidations: s.cfg.MaxConsensusValidations,
+		SSZOnly:                           s.cfg.SSZOnly,
+		ClockDisparityTolerance:           s.cfg.ClockDisparityTolerance,
 	}
+	s.beaconServerV1 = beaconChainServerV1
 	httpServer := &httpserver.Server{
 		GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
 		HeadFetcher:        s.cfg.HeadFetcher,
@@ -405,7 +429,15 @@ func (s *Service) Start() {
 	}
 	s.cfg.Router.HandleFunc("/prysm/validators/performance", httpServer.GetValidatorPerformance).Methods(http.MethodPost)
 	s.cfg.Router.HandleFunc("/eth/v2/beacon/blocks", beaconChainServerV1.PublishBlockV2).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/beacon/blocks/detached_signature", beaconChainServerV1.PublishBlockWithDetachedSignature).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/beacon/blocks/batch", beaconChainServerV1.PublishBlocks).Methods(http.MethodPost)
 	s.cfg.Router.HandleFunc("/eth/v2/beacon/blinded_blocks", beaconChainServerV1.PublishBlindedBlockV2).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/eth/v1/validator/blinded_blocks", beaconChainServerV1.PublishBlindedBlockV2).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/eth/v2/beacon/blocks/{block_id}", beaconChainServerV1.GetBlockV2HTTP).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/blocks/{block_id}/attester_slashings", beaconChainServerV1.GetBlockAttesterSlashings).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/blocks/{block_id}/proposer_slashings", beaconChainServerV1.GetBlockProposerSlashings).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/beacon/blocks/{block_id}/execution_payload", beaconChainServerV1.GetBlockExecutionPayload).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/beacon/fork", beaconChainServerV1.GetFork).Methods(http.MethodGet)
 	ethpbv1alpha1.RegisterNodeServer(s.grpcServer, nodeServer)
 	ethpbservice.RegisterBeaconNodeServer(s.grpcServer, nodeServerEth)
 	ethpbv1alpha1.RegisterHealthServer(s.grpcServer, nodeServer)
@@ -1,9 +1,12 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -11,6 +14,8 @@ import (
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	mockExecution "github.com/prysmaticlabs/prysm/v4/beacon-chain/execution/testing"
 	mockSync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/initial-sync/testing"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/sirupsen/logrus"
@@ -86,3 +91,68 @@ func TestRPC_InsecureEndpoint(t *testing.T) {
 	require.LogsContain(t, hook, "You are using an insecure gRPC server")
 	assert.NoError(t, rpcService.Stop())
 }
+
+func TestValidatorBlindedBlocksRoute(t *testing.T) {
+	chainService := &mock.ChainService{Genesis: time.Now()}
+	router := mux.NewRouter()
+	rpcService := NewService(context.Background(), &Config{
+		Port:                  "7349",
+		SyncService:           &mockSync.Sync{IsSyncing: false},
+		BlockReceiver:         chainService,
+		AttestationReceiver:   chainService,
+		HeadFetcher:           chainService,
+		GenesisTimeFetcher:    chainService,
+		ExecutionChainService: &mockExecution.Chain{},
+		StateNotifier:         chainService.StateNotifier(),
+		Router:                router,
+	})
+
+	rpcService.Start()
+	defer func() {
+		assert.NoError(t, rpcService.Stop())
+	}()
+
+	request := httptest.NewRequest(http.MethodPost, "http://foo.example/eth/v1/validator/blinded_blocks", bytes.NewReader([]byte("{}")))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	router.ServeHTTP(writer, request)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.StringContains(t, "Body does not represent a valid block type", writer.Body.String())
+}
+
+func TestStart_ServerOptions(t *testing.T) {
+	chainService := &mock.ChainService{Genesis: time.Now()}
+	rpcService := NewService(context.Background(), &Config{
+		Port:                    "7350",
+		SyncService:             &mockSync.Sync{IsSyncing: false},
+		BlockReceiver:           chainService,
+		AttestationReceiver:     chainService,
+		HeadFetcher:             chainService,
+		GenesisTimeFetcher:      chainService,
+		ExecutionChainService:   &mockExecution.Chain{},
+		StateNotifier:           chainService.StateNotifier(),
+		Router:                  mux.NewRouter(),
+		StrictAmountValidation:  true,
+		AcceptedForks:           map[int]bool{version.Capella: true},
+		PublishRequestTimeout:   time.Second,
+		LenientJSONDecoding:     true,
+		MaxConsensusValidations: 2,
+		SSZOnly:                 true,
+		DefaultGraffiti:         bytesutil.ToBytes32([]byte("operator")),
+		ClockDisparityTolerance: 500 * time.Millisecond,
+	})
+
+	rpcService.Start()
+	defer func() {
+		assert.NoError(t, rpcService.Stop())
+	}()
+
+	assert.Equal(t, true, rpcService.beaconServerV1.StrictAmountValidation)
+	assert.DeepEqual(t, map[int]bool{version.Capella: true}, rpcService.beaconServerV1.AcceptedForks)
+	assert.Equal(t, time.Second, rpcService.beaconServerV1.RequestTimeout)
+	assert.Equal(t, true, rpcService.beaconServerV1.LenientJSONDecoding)
+	assert.Equal(t, 2, rpcService.beaconServerV1.MaxConcurrentConsensusValidations)
+	assert.Equal(t, true, rpcService.beaconServerV1.SSZOnly)
+	assert.Equal(t, bytesutil.ToBytes32([]byte("operator")), rpcService.validatorServerV1.DefaultGraffiti)
+	assert.Equal(t, 500*time.Millisecond, rpcService.beaconServerV1.ClockDisparityTolerance)
+}
@@ -0,0 +1,44 @@
+package testutil
+
+import (
+	"context"
+
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// MockValidatorServer is a fake implementation of the subset of eth.BeaconNodeValidatorServer
+// used by the Beacon API servers.
+type MockValidatorServer struct {
+	BlockToReturn     *eth.GenericBeaconBlock
+	ErrorToReturn     error
+	ProposedBlocks    []*eth.GenericSignedBeaconBlock
+	SubmittedSyncMsgs []*eth.SyncCommitteeMessage
+	ProposeBlockRoot  []byte
+}
+
+// GetBeaconBlock --
+func (m *MockValidatorServer) GetBeaconBlock(_ context.Context, _ *eth.BlockRequest) (*eth.GenericBeaconBlock, error) {
+	if m.ErrorToReturn != nil {
+		return nil, m.ErrorToReturn
+	}
+	return m.BlockToReturn, nil
+}
+
+// ProposeBeaconBlock --
+func (m *MockValidatorServer) ProposeBeaconBlock(_ context.Context, blk *eth.GenericSignedBeaconBlock) (*eth.ProposeResponse, error) {
+	if m.ErrorToReturn != nil {
+		return nil, m.ErrorToReturn
+	}
+	m.ProposedBlocks = append(m.ProposedBlocks, blk)
+	return &eth.ProposeResponse{BlockRoot: m.ProposeBlockRoot}, nil
+}
+
+// SubmitSyncMessage --
+func (m *MockValidatorServer) SubmitSyncMessage(_ context.Context, msg *eth.SyncCommitteeMessage) (*emptypb.Empty, error) {
+	if m.ErrorToReturn != nil {
+		return nil, m.ErrorToReturn
+	}
+	m.SubmittedSyncMsgs = append(m.SubmittedSyncMsgs, msg)
+	return &emptypb.Empty{}, nil
+}
@@ -30,6 +30,51 @@ var (
 		Usage: "A percentage boost for local block construction. This is used to prioritize local block construction over relay/builder block construction" +
 			"Boost is an additional percentage to multiple local block value. Use builder block if: builder_bid_value * 100 > local_block_value * (local-block-value-boost + 100)",
 	}
+	// ExcludedBuilderPubkeys defines the builders whose bids are never used for block construction.
+	ExcludedBuilderPubkeys = &cli.StringSliceFlag{
+		Name:  "excluded-builder-pubkeys",
+		Usage: "Comma-separated list of relay/builder public keys whose bids are rejected, falling back to local block construction",
+	}
+	// StrictBlockAmountValidation enables sanity checks of the deposit and withdrawal amounts of published blocks.
+	StrictBlockAmountValidation = &cli.BoolFlag{
+		Name:  "strict-block-amount-validation",
+		Usage: "Rejects published blocks with deposit or withdrawal amounts that cannot be valid, before broadcasting them",
+	}
+	// BlockPublishingForks restricts the forks whose blocks can be published through the beacon API.
+	BlockPublishingForks = &cli.StringSliceFlag{
+		Name:  "block-publishing-forks",
+		Usage: "Comma-separated list of fork names, e.g. bellatrix,capella, whose blocks can be published through the beacon API. All forks are accepted if not set",
+	}
+	// BlockPublishingTimeout bounds how long a request publishing a block through the beacon API can take.
+	BlockPublishingTimeout = &cli.DurationFlag{
+		Name:  "block-publishing-timeout",
+		Usage: "Maximum duration of a request publishing a block through the beacon API, e.g. 12s. Requests are not bounded if not set",
+	}
+	// LenientBlockJSONDecoding accepts unknown fields in blocks published as JSON through the beacon API.
+	LenientBlockJSONDecoding = &cli.BoolFlag{
+		Name:  "lenient-block-json-decoding",
+		Usage: "Ignores unknown fields in blocks published as JSON instead of rejecting them, e.g. for clients already sending fields of newer forks",
+	}
+	// MaxConcurrentConsensusValidations bounds the number of published blocks going through consensus validation at the same time.
+	MaxConcurrentConsensusValidations = &cli.IntFlag{
+		Name:  "max-concurrent-consensus-validations",
+		Usage: "Maximum number of blocks published with consensus broadcast validation whose state transition can run at the same time. Not limited if not set",
+	}
+	// SSZOnlyBlockPublishing rejects blocks published as JSON through the beacon API.
+	SSZOnlyBlockPublishing = &cli.BoolFlag{
+		Name:  "ssz-only-block-publishing",
+		Usage: "Only accepts blocks published as SSZ through the beacon API, which saves the CPU spent on decoding JSON blocks",
+	}
+	// DefaultGraffiti is included in blocks produced through the beacon API without a requested graffiti.
+	DefaultGraffiti = &cli.StringFlag{
+		Name:  "default-graffiti",
+		Usage: "Graffiti of at most 32 bytes included in produced blocks whose validator does not request one",
+	}
+	// BlockPublishingClockDisparity is how early a block can be published before the start of its slot.
+	BlockPublishingClockDisparity = &cli.DurationFlag{
+		Name:  "block-publishing-clock-disparity",
+		Usage: "How early a block published with consensus broadcast validation can arrive before the start of its slot, e.g. 500ms, for proposers whose clock is ahead",
+	}
 	// ExecutionEngineEndpoint provides an HTTP access endpoint to connect to an execution client on the execution layer
 	ExecutionEngineEndpoint = &cli.StringFlag{
 		Name:  "execution-endpoint",
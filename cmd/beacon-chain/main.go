@@ -74,6 +74,15 @@ var appFlags = []cli.Flag{
 	flags.MaxBuilderConsecutiveMissedSlots,
 	flags.EngineEndpointTimeoutSeconds,
 	flags.LocalBlockValueBoost,
+	flags.ExcludedBuilderPubkeys,
+	flags.StrictBlockAmountValidation,
+	flags.BlockPublishingForks,
+	flags.BlockPublishingTimeout,
+	flags.LenientBlockJSONDecoding,
+	flags.MaxConcurrentConsensusValidations,
+	flags.SSZOnlyBlockPublishing,
+	flags.DefaultGraffiti,
+	flags.BlockPublishingClockDisparity,
 	cmd.BackupWebhookOutputDir,
 	cmd.MinimalConfigFlag,
 	cmd.E2EConfigFlag,
@@ -126,6 +126,15 @@ var appHelpFlagGroups = []flagGroup{
 			flags.EngineEndpointTimeoutSeconds,
 			flags.SlasherDirFlag,
 			flags.LocalBlockValueBoost,
+			flags.ExcludedBuilderPubkeys,
+			flags.StrictBlockAmountValidation,
+			flags.BlockPublishingForks,
+			flags.BlockPublishingTimeout,
+			flags.LenientBlockJSONDecoding,
+			flags.MaxConcurrentConsensusValidations,
+			flags.SSZOnlyBlockPublishing,
+			flags.DefaultGraffiti,
+			flags.BlockPublishingClockDisparity,
 			checkpoint.BlockPath,
 			checkpoint.StatePath,
 			checkpoint.RemoteURL,
@@ -0,0 +1,320 @@
+package blocks
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	fastssz "github.com/prysmaticlabs/fastssz"
+	field_params "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+)
+
+const (
+	// bytesPerLengthOffset is the size of the offsets pointing to the variable-size fields of SSZ containers and lists.
+	bytesPerLengthOffset = 4
+	// maxExtraDataLength is the limit of the extra data of an execution payload, MAX_EXTRA_DATA_BYTES.
+	maxExtraDataLength = 32
+)
+
+// sszPart is a field of an SSZ container. Fixed-size parts are written in the fixed-size section of the container,
+// variable-size parts are referenced by an offset there and written after it. A part that can't be encoded
+// carries the error, which is returned before anything is written.
+type sszPart struct {
+	variable bool
+	size     int
+	err      error
+	write    func(w io.Writer) error
+}
+
+// MarshalSSZToWriter writes the SSZ encoding of the signed block to w. Unlike MarshalSSZ, it encodes one attestation,
+// transaction or other list item at a time, so that the encoding of the whole block is never held in memory.
+func (b *SignedBeaconBlock) MarshalSSZToWriter(w io.Writer) error {
+	write, err := b.PrepareSSZWriter()
+	if err != nil {
+		return err
+	}
+	return write(w)
+}
+
+// PrepareSSZWriter makes the checks of MarshalSSZ, and returns a function writing the SSZ encoding of the signed
+// block to w. Since the checks are made before anything is written, the returned function only fails when
+// writing to w does. Only the transactions, which make up the bulk of a block, are written from the block as
+// they are; the other parts are encoded here.
+func (b *SignedBeaconBlock) PrepareSSZWriter() (func(w io.Writer) error, error) {
+	if b == nil || b.block == nil || b.block.body == nil {
+		return nil, errNilBlock
+	}
+	body, err := b.block.body.sszParts()
+	if err != nil {
+		return nil, err
+	}
+	block := []sszPart{
+		uint64Part(uint64(b.block.slot)),
+		uint64Part(uint64(b.block.proposerIndex)),
+		fixedBytesPart(b.block.parentRoot[:], field_params.RootLength),
+		fixedBytesPart(b.block.stateRoot[:], field_params.RootLength),
+		containerPart(body),
+	}
+	parts := []sszPart{
+		containerPart(block),
+		fixedBytesPart(b.signature[:], field_params.BLSSignatureLength),
+	}
+	for _, p := range parts {
+		if p.err != nil {
+			return nil, p.err
+		}
+	}
+	return func(w io.Writer) error {
+		return writeSSZContainer(w, parts)
+	}, nil
+}
+
+func (b *BeaconBlockBody) sszParts() ([]sszPart, error) {
+	if b.version < version.Phase0 || b.version > version.Capella {
+		return nil, errIncorrectBodyVersion
+	}
+	eth1Data := b.eth1Data
+	if eth1Data == nil {
+		eth1Data = &eth.Eth1Data{}
+	}
+	cfg := params.BeaconConfig()
+	parts := []sszPart{
+		fixedBytesPart(b.randaoReveal[:], field_params.BLSSignatureLength),
+		objectPart(eth1Data, false),
+		fixedBytesPart(b.graffiti[:], field_params.RootLength),
+		fixedListPart("ProposerSlashings", b.proposerSlashings, cfg.MaxProposerSlashings),
+		variableListPart("AttesterSlashings", b.attesterSlashings, cfg.MaxAttesterSlashings),
+		variableListPart("Attestations", b.attestations, cfg.MaxAttestations),
+		fixedListPart("Deposits", b.deposits, cfg.MaxDeposits),
+		fixedListPart("VoluntaryExits", b.voluntaryExits, cfg.MaxVoluntaryExits),
+	}
+	if b.version == version.Phase0 {
+		return parts, nil
+	}
+	syncAggregate := b.syncAggregate
+	if syncAggregate == nil {
+		syncAggregate = &eth.SyncAggregate{}
+	}
+	parts = append(parts, objectPart(syncAggregate, false))
+	if b.version == version.Altair {
+		return parts, nil
+	}
+	if b.isBlinded {
+		// The header is small, unlike the payload, so it is encoded as a whole.
+		if b.executionPayloadHeader == nil {
+			return nil, errPayloadHeaderWrongType
+		}
+		parts = append(parts, objectPart(b.executionPayloadHeader, true))
+	} else {
+		payload, err := executionPayloadSSZParts(b.executionPayload, b.version)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, containerPart(payload))
+	}
+	if b.version == version.Bellatrix {
+		return parts, nil
+	}
+	return append(parts, fixedListPart("BlsToExecutionChanges", b.blsToExecutionChanges, cfg.MaxBlsToExecutionChanges)), nil
+}
+
+func executionPayloadSSZParts(p interfaces.ExecutionData, v int) ([]sszPart, error) {
+	if p == nil || p.IsNil() {
+		return nil, errPayloadWrongType
+	}
+	txs, err := p.Transactions()
+	if err != nil {
+		return nil, err
+	}
+	parts := []sszPart{
+		fixedBytesPart(p.ParentHash(), field_params.RootLength),
+		fixedBytesPart(p.FeeRecipient(), field_params.FeeRecipientLength),
+		fixedBytesPart(p.StateRoot(), field_params.RootLength),
+		fixedBytesPart(p.ReceiptsRoot(), field_params.RootLength),
+		fixedBytesPart(p.LogsBloom(), field_params.LogsBloomLength),
+		fixedBytesPart(p.PrevRandao(), field_params.RootLength),
+		uint64Part(p.BlockNumber()),
+		uint64Part(p.GasLimit()),
+		uint64Part(p.GasUsed()),
+		uint64Part(p.Timestamp()),
+		variableBytesPart("ExtraData", p.ExtraData(), maxExtraDataLength),
+		fixedBytesPart(p.BaseFeePerGas(), field_params.RootLength),
+		fixedBytesPart(p.BlockHash(), field_params.RootLength),
+		transactionsPart(txs),
+	}
+	if v == version.Bellatrix {
+		return parts, nil
+	}
+	withdrawals, err := p.Withdrawals()
+	if err != nil {
+		return nil, err
+	}
+	return append(parts, fixedListPart("Withdrawals", withdrawals, field_params.MaxWithdrawalsPerPayload)), nil
+}
+
+// writeSSZContainer writes the fixed-size section of the container, with offsets in place of the variable-size
+// parts, followed by the variable-size parts.
+func writeSSZContainer(w io.Writer, parts []sszPart) error {
+	offset := 0
+	for _, p := range parts {
+		if p.variable {
+			offset += bytesPerLengthOffset
+		} else {
+			offset += p.size
+		}
+	}
+	for _, p := range parts {
+		if !p.variable {
+			if err := p.write(w); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := writeOffset(w, offset); err != nil {
+			return err
+		}
+		offset += p.size
+	}
+	for _, p := range parts {
+		if !p.variable {
+			continue
+		}
+		if err := p.write(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeOffset(w io.Writer, offset int) error {
+	var b [bytesPerLengthOffset]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(offset))
+	_, err := w.Write(b[:])
+	return err
+}
+
+func errorPart(err error) sszPart {
+	return sszPart{err: err}
+}
+
+func containerPart(parts []sszPart) sszPart {
+	size := 0
+	for _, p := range parts {
+		if p.err != nil {
+			return errorPart(p.err)
+		}
+		size += p.size
+		if p.variable {
+			size += bytesPerLengthOffset
+		}
+	}
+	return sszPart{variable: true, size: size, write: func(w io.Writer) error {
+		return writeSSZContainer(w, parts)
+	}}
+}
+
+func uint64Part(v uint64) sszPart {
+	return sszPart{size: 8, write: func(w io.Writer) error {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], v)
+		_, err := w.Write(b[:])
+		return err
+	}}
+}
+
+// fixedBytesPart is a byte vector of the given length. Its offset is computed from the expected length,
+// so a value of a different length is rejected rather than corrupting the encoding.
+func fixedBytesPart(b []byte, size int) sszPart {
+	if len(b) != size {
+		return errorPart(fmt.Errorf("field has length %d bytes, expected %d bytes", len(b), size))
+	}
+	return bytesPart(b, false)
+}
+
+func variableBytesPart(name string, b []byte, max int) sszPart {
+	if len(b) > max {
+		return errorPart(fastssz.ErrBytesLengthFn("--."+name, len(b), max))
+	}
+	return bytesPart(b, true)
+}
+
+func bytesPart(b []byte, variable bool) sszPart {
+	return sszPart{variable: variable, size: len(b), write: func(w io.Writer) error {
+		_, err := w.Write(b)
+		return err
+	}}
+}
+
+// objectPart is an SSZ object small enough to be encoded as a whole.
+func objectPart(m fastssz.Marshaler, variable bool) sszPart {
+	enc, err := m.MarshalSSZ()
+	if err != nil {
+		return errorPart(err)
+	}
+	return bytesPart(enc, variable)
+}
+
+// fixedListPart is a list of fixed-size items, which are written one after another.
+func fixedListPart[T fastssz.Marshaler](name string, items []T, max uint64) sszPart {
+	if uint64(len(items)) > max {
+		return errorPart(fastssz.ErrListTooBigFn("--."+name, len(items), int(max)))
+	}
+	var enc []byte
+	for _, item := range items {
+		var err error
+		if enc, err = item.MarshalSSZTo(enc); err != nil {
+			return errorPart(err)
+		}
+	}
+	return bytesPart(enc, true)
+}
+
+// variableListPart is a list of variable-size items, whose offsets are written before the items themselves.
+func variableListPart[T fastssz.Marshaler](name string, items []T, max uint64) sszPart {
+	if uint64(len(items)) > max {
+		return errorPart(fastssz.ErrListTooBigFn("--."+name, len(items), int(max)))
+	}
+	enc := make([]byte, bytesPerLengthOffset*len(items))
+	for i, item := range items {
+		binary.LittleEndian.PutUint32(enc[bytesPerLengthOffset*i:], uint32(len(enc)))
+		var err error
+		if enc, err = item.MarshalSSZTo(enc); err != nil {
+			return errorPart(err)
+		}
+	}
+	return bytesPart(enc, true)
+}
+
+// transactionsPart is the list of transactions of an execution payload. Transactions are written from
+// the payload as they are, so that they are never copied.
+func transactionsPart(txs [][]byte) sszPart {
+	if len(txs) > field_params.MaxTxsPerPayloadLength {
+		return errorPart(fastssz.ErrListTooBigFn("--.Transactions", len(txs), field_params.MaxTxsPerPayloadLength))
+	}
+	size := bytesPerLengthOffset * len(txs)
+	for _, tx := range txs {
+		if len(tx) > field_params.MaxBytesPerTxLength {
+			return errorPart(fastssz.ErrBytesLengthFn("--.Transactions[ii]", len(tx), field_params.MaxBytesPerTxLength))
+		}
+		size += len(tx)
+	}
+	return sszPart{variable: true, size: size, write: func(w io.Writer) error {
+		offset := bytesPerLengthOffset * len(txs)
+		for _, tx := range txs {
+			if err := writeOffset(w, offset); err != nil {
+				return err
+			}
+			offset += len(tx)
+		}
+		for _, tx := range txs {
+			if _, err := w.Write(tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}}
+}
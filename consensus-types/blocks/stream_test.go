@@ -0,0 +1,145 @@
+package blocks
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	field_params "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	enginev1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func Test_SignedBeaconBlock_MarshalSSZToWriter(t *testing.T) {
+	f := getFields()
+	tests := []struct {
+		name string
+		blk  interface{}
+	}{
+		{name: "phase0", blk: &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 128, ProposerIndex: 128, ParentRoot: f.root[:], StateRoot: f.root[:], Body: bodyPbPhase0()}, Signature: f.sig[:]}},
+		{name: "altair", blk: &eth.SignedBeaconBlockAltair{Block: &eth.BeaconBlockAltair{Slot: 128, ProposerIndex: 128, ParentRoot: f.root[:], StateRoot: f.root[:], Body: bodyPbAltair()}, Signature: f.sig[:]}},
+		{name: "bellatrix", blk: &eth.SignedBeaconBlockBellatrix{Block: &eth.BeaconBlockBellatrix{Slot: 128, ProposerIndex: 128, ParentRoot: f.root[:], StateRoot: f.root[:], Body: bodyPbBellatrix()}, Signature: f.sig[:]}},
+		{name: "blinded bellatrix", blk: &eth.SignedBlindedBeaconBlockBellatrix{Block: &eth.BlindedBeaconBlockBellatrix{Slot: 128, ProposerIndex: 128, ParentRoot: f.root[:], StateRoot: f.root[:], Body: bodyPbBlindedBellatrix()}, Signature: f.sig[:]}},
+		{name: "capella", blk: &eth.SignedBeaconBlockCapella{Block: &eth.BeaconBlockCapella{Slot: 128, ProposerIndex: 128, ParentRoot: f.root[:], StateRoot: f.root[:], Body: bodyPbCapella()}, Signature: f.sig[:]}},
+		{name: "blinded capella", blk: &eth.SignedBlindedBeaconBlockCapella{Block: &eth.BlindedBeaconBlockCapella{Slot: 128, ProposerIndex: 128, ParentRoot: f.root[:], StateRoot: f.root[:], Body: bodyPbBlindedCapella()}, Signature: f.sig[:]}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb, err := NewSignedBeaconBlock(tt.blk)
+			require.NoError(t, err)
+			b, ok := sb.(*SignedBeaconBlock)
+			require.Equal(t, true, ok)
+			expected, err := b.MarshalSSZ()
+			require.NoError(t, err)
+			var buf bytes.Buffer
+			require.NoError(t, b.MarshalSSZToWriter(&buf))
+			assert.DeepEqual(t, expected, buf.Bytes())
+			assert.Equal(t, b.SizeSSZ(), buf.Len())
+		})
+	}
+	t.Run("nil block", func(t *testing.T) {
+		var b *SignedBeaconBlock
+		require.ErrorIs(t, b.MarshalSSZToWriter(&bytes.Buffer{}), errNilBlock)
+	})
+	t.Run("invalid field length", func(t *testing.T) {
+		pb := &eth.SignedBeaconBlockBellatrix{Block: &eth.BeaconBlockBellatrix{Slot: 128, ProposerIndex: 128, ParentRoot: f.root[:], StateRoot: f.root[:], Body: bodyPbBellatrix()}, Signature: f.sig[:]}
+		pb.Block.Body.ExecutionPayload.ParentHash = []byte{0x01}
+		b, err := NewSignedBeaconBlock(pb)
+		require.NoError(t, err)
+		err = b.(*SignedBeaconBlock).MarshalSSZToWriter(&bytes.Buffer{})
+		assert.ErrorContains(t, "field has length 1 bytes, expected 32 bytes", err)
+	})
+	t.Run("list limits", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			modify func(body *eth.BeaconBlockBodyCapella)
+			errMsg string
+		}{
+			{
+				name: "attestations",
+				modify: func(body *eth.BeaconBlockBodyCapella) {
+					att := body.Attestations[0]
+					body.Attestations = make([]*eth.Attestation, params.BeaconConfig().MaxAttestations+1)
+					for i := range body.Attestations {
+						body.Attestations[i] = att
+					}
+				},
+				errMsg: "--.Attestations",
+			},
+			{
+				name:   "extra data",
+				modify: func(body *eth.BeaconBlockBodyCapella) { body.ExecutionPayload.ExtraData = make([]byte, 33) },
+				errMsg: "--.ExtraData",
+			},
+			{
+				name: "withdrawals",
+				modify: func(body *eth.BeaconBlockBodyCapella) {
+					body.ExecutionPayload.Withdrawals = make([]*enginev1.Withdrawal, field_params.MaxWithdrawalsPerPayload+1)
+					for i := range body.ExecutionPayload.Withdrawals {
+						body.ExecutionPayload.Withdrawals[i] = &enginev1.Withdrawal{Address: make([]byte, field_params.FeeRecipientLength)}
+					}
+				},
+				errMsg: "--.Withdrawals",
+			},
+			{
+				name:   "attestation item",
+				modify: func(body *eth.BeaconBlockBodyCapella) { body.Attestations[0].Signature = []byte{0x01} },
+				errMsg: "--.Signature",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				body := bodyPbCapella()
+				tt.modify(body)
+				blk, err := NewSignedBeaconBlock(&eth.SignedBeaconBlockCapella{Block: &eth.BeaconBlockCapella{Slot: 128, ProposerIndex: 128, ParentRoot: f.root[:], StateRoot: f.root[:], Body: body}, Signature: f.sig[:]})
+				require.NoError(t, err)
+				b := blk.(*SignedBeaconBlock)
+				_, err = b.MarshalSSZ()
+				assert.ErrorContains(t, tt.errMsg, err)
+				_, err = b.PrepareSSZWriter()
+				assert.ErrorContains(t, tt.errMsg, err)
+			})
+		}
+	})
+	t.Run("nothing written on error", func(t *testing.T) {
+		body := bodyPbCapella()
+		body.ExecutionPayload.ExtraData = make([]byte, 33)
+		blk, err := NewSignedBeaconBlock(&eth.SignedBeaconBlockCapella{Block: &eth.BeaconBlockCapella{Slot: 128, ProposerIndex: 128, ParentRoot: f.root[:], StateRoot: f.root[:], Body: body}, Signature: f.sig[:]})
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NotNil(t, blk.(*SignedBeaconBlock).MarshalSSZToWriter(&buf))
+		assert.Equal(t, 0, buf.Len())
+	})
+}
+
+func Benchmark_SignedBeaconBlock_MarshalSSZToWriter(b *testing.B) {
+	f := getFields()
+	body := bodyPbCapella()
+	// Roughly the size of a full block at the gossip size limit.
+	body.ExecutionPayload.Transactions = make([][]byte, 10<<10)
+	for i := range body.ExecutionPayload.Transactions {
+		body.ExecutionPayload.Transactions[i] = make([]byte, 1<<10)
+	}
+	blk, err := NewSignedBeaconBlock(&eth.SignedBeaconBlockCapella{Block: &eth.BeaconBlockCapella{Slot: 128, ProposerIndex: 128, ParentRoot: f.root[:], StateRoot: f.root[:], Body: body}, Signature: f.sig[:]})
+	require.NoError(b, err)
+	sb, ok := blk.(*SignedBeaconBlock)
+	require.Equal(b, true, ok)
+	b.Run("marshal then write", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			enc, err := sb.MarshalSSZ()
+			require.NoError(b, err)
+			_, err = io.Discard.Write(enc)
+			require.NoError(b, err)
+		}
+	})
+	b.Run("stream", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, sb.MarshalSSZToWriter(io.Discard))
+		}
+	})
+}
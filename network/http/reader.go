@@ -1,15 +1,29 @@
 package http
 
 import (
+	"bytes"
+	"io"
+	"mime"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+)
+
+const (
+	encodingQueryParam = "encoding"
+	sszSnappyEncoding  = "ssz_snappy"
 )
 
 // match a number with optional decimals
 var priorityRegex = regexp.MustCompile(`q=(\d+(?:\.\d+)?)`)
 
+// ErrSszSnappyTooLarge is returned when snappy framed SSZ decodes to more than the allowed size.
+var ErrSszSnappyTooLarge = errors.New("decoded snappy data exceeds the maximum allowed size")
+
 // SszRequested takes a http request and checks to see if it should be requesting a ssz response.
 func SszRequested(req *http.Request) (bool, error) {
 	accept := req.Header.Values("Accept")
@@ -49,3 +63,32 @@ func SszRequested(req *http.Request) (bool, error) {
 
 	return currentType == octetStreamMediaType, nil
 }
+
+// SszSnappyRequested checks whether the request asks for, or carries, snappy framed SSZ. This is the case
+// when the `encoding` query parameter is `ssz_snappy`, when the Accept header lists the snappy media type,
+// or when the Content-Type header is the snappy media type.
+func SszSnappyRequested(req *http.Request) bool {
+	if req.URL.Query().Get(encodingQueryParam) == sszSnappyEncoding {
+		return true
+	}
+	for _, t := range strings.Split(req.Header.Get("Accept"), ",") {
+		if strings.TrimSpace(strings.Split(t, ";")[0]) == octetStreamSnappyMediaType {
+			return true
+		}
+	}
+	contentType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return err == nil && contentType == octetStreamSnappyMediaType
+}
+
+// DecodeSszSnappy decodes snappy framed SSZ. ErrSszSnappyTooLarge is returned when the decoded data
+// would be larger than maxSize bytes.
+func DecodeSszSnappy(data []byte, maxSize int64) ([]byte, error) {
+	decoded, err := io.ReadAll(io.LimitReader(snappy.NewReader(bytes.NewReader(data)), maxSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode snappy data")
+	}
+	if int64(len(decoded)) > maxSize {
+		return nil, ErrSszSnappyTooLarge
+	}
+	return decoded, nil
+}
@@ -1,10 +1,12 @@
 package http
 
 import (
+	"bytes"
 	"fmt"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/golang/snappy"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 )
@@ -97,3 +99,48 @@ func TestSSZRequested(t *testing.T) {
 		assert.Equal(t, false, result)
 	})
 }
+
+func TestSszSnappyRequested(t *testing.T) {
+	t.Run("query", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example?encoding=ssz_snappy", nil)
+		assert.Equal(t, true, SszSnappyRequested(request))
+	})
+	t.Run("accept", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example", nil)
+		request.Header.Set("Accept", fmt.Sprintf("%s;q=0.9,%s", jsonMediaType, octetStreamSnappyMediaType))
+		assert.Equal(t, true, SszSnappyRequested(request))
+	})
+	t.Run("content type", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example", nil)
+		request.Header.Set("Content-Type", octetStreamSnappyMediaType)
+		assert.Equal(t, true, SszSnappyRequested(request))
+	})
+	t.Run("plain ssz", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example?encoding=ssz", nil)
+		request.Header.Set("Accept", octetStreamMediaType)
+		request.Header.Set("Content-Type", octetStreamMediaType)
+		assert.Equal(t, false, SszSnappyRequested(request))
+	})
+}
+
+func TestDecodeSszSnappy(t *testing.T) {
+	var buf bytes.Buffer
+	sw := snappy.NewBufferedWriter(&buf)
+	_, err := sw.Write([]byte("foo"))
+	require.NoError(t, err)
+	require.NoError(t, sw.Close())
+
+	t.Run("ok", func(t *testing.T) {
+		decoded, err := DecodeSszSnappy(buf.Bytes(), 3)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []byte("foo"), decoded)
+	})
+	t.Run("too large", func(t *testing.T) {
+		_, err := DecodeSszSnappy(buf.Bytes(), 2)
+		assert.Equal(t, ErrSszSnappyTooLarge, err)
+	})
+	t.Run("not snappy framed", func(t *testing.T) {
+		_, err := DecodeSszSnappy([]byte("foo"), 3)
+		assert.ErrorContains(t, "could not decode snappy data", err)
+	})
+}
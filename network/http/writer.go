@@ -6,13 +6,16 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"sync"
 
+	"github.com/golang/snappy"
 	log "github.com/sirupsen/logrus"
 )
 
 const (
-	jsonMediaType        = "application/json"
-	octetStreamMediaType = "application/octet-stream"
+	jsonMediaType              = "application/json"
+	octetStreamMediaType       = "application/octet-stream"
+	octetStreamSnappyMediaType = "application/octet-stream+snappy"
 )
 
 // DefaultErrorJson is a JSON representation of a simple error value, containing only a message and an error code.
@@ -23,23 +26,110 @@ type DefaultErrorJson struct {
 
 // WriteJson writes the response message in JSON format.
 func WriteJson(w http.ResponseWriter, v any) {
+	WriteJsonWithStatus(w, http.StatusOK, v)
+}
+
+// WriteJsonWithStatus writes the response message in JSON format with the given status code.
+func WriteJsonWithStatus(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", jsonMediaType)
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 	if err := json.NewEncoder(w).Encode(v); err != nil {
 		log.WithError(err).Error("Could not write response message")
 	}
 }
 
+// SszMarshaler is a value that can be SSZ encoded into a provided buffer.
+type SszMarshaler interface {
+	SizeSSZ() int
+	MarshalSSZTo(dst []byte) ([]byte, error)
+}
+
+// SszStreamer is a value that can write its SSZ encoding directly to a writer. PrepareSSZWriter makes
+// all the checks of the encoding, so the function it returns only fails when writing to the writer does.
+type SszStreamer interface {
+	PrepareSSZWriter() (func(w io.Writer) error, error)
+}
+
+// sszBufferPool holds buffers reused across SSZ responses, so that encoding a large
+// object does not allocate a new buffer of its full size on every request.
+var sszBufferPool = sync.Pool{
+	New: func() any {
+		return new([]byte)
+	},
+}
+
 // WriteSsz writes the response message in ssz format
 func WriteSsz(w http.ResponseWriter, respSsz []byte, fileName string) {
-	w.Header().Set("Content-Length", strconv.Itoa(len(respSsz)))
-	w.Header().Set("Content-Type", octetStreamMediaType)
-	w.Header().Set("Content-Disposition", "attachment; filename="+fileName)
+	setSszHeaders(w, octetStreamMediaType, len(respSsz), fileName)
 	if _, err := io.Copy(w, io.NopCloser(bytes.NewReader(respSsz))); err != nil {
 		log.WithError(err).Error("could not write response message")
 	}
 }
 
+// WriteSszFrom writes the SSZ encoding of v as the response message. Values implementing SszStreamer
+// are written directly to the response. Other values are encoded into a pooled buffer first.
+// An error is returned, and nothing is written, when v can't be encoded.
+func WriteSszFrom(w http.ResponseWriter, v SszMarshaler, fileName string) error {
+	size := v.SizeSSZ()
+	if s, ok := v.(SszStreamer); ok {
+		write, err := s.PrepareSSZWriter()
+		if err != nil {
+			return err
+		}
+		setSszHeaders(w, octetStreamMediaType, size, fileName)
+		if err := write(w); err != nil {
+			log.WithError(err).Error("could not write response message")
+		}
+		return nil
+	}
+	bufPtr, ok := sszBufferPool.Get().(*[]byte)
+	if !ok {
+		bufPtr = new([]byte)
+	}
+	defer sszBufferPool.Put(bufPtr)
+	if cap(*bufPtr) < size {
+		*bufPtr = make([]byte, 0, size)
+	}
+	buf, err := v.MarshalSSZTo((*bufPtr)[:0])
+	if err != nil {
+		return err
+	}
+	*bufPtr = buf
+	setSszHeaders(w, octetStreamMediaType, len(buf), fileName)
+	if _, err := w.Write(buf); err != nil {
+		log.WithError(err).Error("could not write response message")
+	}
+	return nil
+}
+
+// WriteSszSnappyFrom writes the snappy framed SSZ encoding of v as the response message.
+// An error is returned, and nothing is written, when v can't be encoded.
+func WriteSszSnappyFrom(w http.ResponseWriter, v SszMarshaler, fileName string) error {
+	sszBytes, err := v.MarshalSSZTo(make([]byte, 0, v.SizeSSZ()))
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	sw := snappy.NewBufferedWriter(&buf)
+	if _, err = sw.Write(sszBytes); err != nil {
+		return err
+	}
+	if err = sw.Close(); err != nil {
+		return err
+	}
+	setSszHeaders(w, octetStreamSnappyMediaType, buf.Len(), fileName)
+	if _, err = io.Copy(w, &buf); err != nil {
+		log.WithError(err).Error("could not write response message")
+	}
+	return nil
+}
+
+func setSszHeaders(w http.ResponseWriter, mediaType string, size int, fileName string) {
+	w.Header().Set("Content-Length", strconv.Itoa(size))
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Disposition", "attachment; filename="+fileName)
+}
+
 // WriteError writes the error by manipulating headers and the body of the final response.
 func WriteError(w http.ResponseWriter, errJson *DefaultErrorJson) {
 	j, err := json.Marshal(errJson)
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+type testSszValue struct {
+	data []byte
+	err  error
+}
+
+func (v *testSszValue) SizeSSZ() int {
+	return len(v.data)
+}
+
+func (v *testSszValue) MarshalSSZTo(dst []byte) ([]byte, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	return append(dst, v.data...), nil
+}
+
+func (v *testSszValue) MarshalSSZ() ([]byte, error) {
+	return v.MarshalSSZTo(nil)
+}
+
+type testSszStreamer struct {
+	testSszValue
+	streamed bool
+}
+
+func (v *testSszStreamer) PrepareSSZWriter() (func(w io.Writer) error, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	return func(w io.Writer) error {
+		v.streamed = true
+		_, err := w.Write(v.data)
+		return err
+	}, nil
+}
+
+func TestWriteSszFrom(t *testing.T) {
+	t.Run("buffered", func(t *testing.T) {
+		v := &testSszValue{data: []byte("foo")}
+		writer := httptest.NewRecorder()
+		require.NoError(t, WriteSszFrom(writer, v, "foo.ssz"))
+		assert.DeepEqual(t, []byte("foo"), writer.Body.Bytes())
+		assert.Equal(t, "3", writer.Header().Get("Content-Length"))
+		assert.Equal(t, octetStreamMediaType, writer.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=foo.ssz", writer.Header().Get("Content-Disposition"))
+
+		// A pooled buffer holding a previous, longer response must not leak into the next one.
+		v = &testSszValue{data: []byte("ab")}
+		writer = httptest.NewRecorder()
+		require.NoError(t, WriteSszFrom(writer, v, "foo.ssz"))
+		assert.DeepEqual(t, []byte("ab"), writer.Body.Bytes())
+	})
+	t.Run("streamed", func(t *testing.T) {
+		v := &testSszStreamer{testSszValue: testSszValue{data: []byte("foo")}}
+		writer := httptest.NewRecorder()
+		require.NoError(t, WriteSszFrom(writer, v, "foo.ssz"))
+		assert.Equal(t, true, v.streamed)
+		assert.DeepEqual(t, []byte("foo"), writer.Body.Bytes())
+		assert.Equal(t, "3", writer.Header().Get("Content-Length"))
+	})
+	t.Run("stream error", func(t *testing.T) {
+		v := &testSszStreamer{testSszValue: testSszValue{err: errors.New("foo")}}
+		writer := httptest.NewRecorder()
+		assert.ErrorContains(t, "foo", WriteSszFrom(writer, v, "foo.ssz"))
+		assert.Equal(t, false, v.streamed)
+		assert.Equal(t, 0, writer.Body.Len())
+		assert.Equal(t, "", writer.Header().Get("Content-Length"))
+	})
+	t.Run("marshal error", func(t *testing.T) {
+		v := &testSszValue{err: errors.New("foo")}
+		writer := httptest.NewRecorder()
+		assert.ErrorContains(t, "foo", WriteSszFrom(writer, v, "foo.ssz"))
+		assert.Equal(t, 0, writer.Body.Len())
+		assert.Equal(t, "", writer.Header().Get("Content-Type"))
+	})
+}
+
+func TestWriteSszSnappyFrom(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		require.NoError(t, WriteSszSnappyFrom(writer, &testSszValue{data: []byte("foo")}, "foo.ssz_snappy"))
+		assert.Equal(t, octetStreamSnappyMediaType, writer.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=foo.ssz_snappy", writer.Header().Get("Content-Disposition"))
+		assert.Equal(t, strconv.Itoa(writer.Body.Len()), writer.Header().Get("Content-Length"))
+		decoded, err := DecodeSszSnappy(writer.Body.Bytes(), 3)
+		require.NoError(t, err)
+		assert.DeepEqual(t, []byte("foo"), decoded)
+	})
+	t.Run("marshal error", func(t *testing.T) {
+		writer := httptest.NewRecorder()
+		err := WriteSszSnappyFrom(writer, &testSszValue{err: errors.New("bad")}, "foo.ssz_snappy")
+		assert.ErrorContains(t, "bad", err)
+		assert.Equal(t, 0, writer.Body.Len())
+		assert.Equal(t, "", writer.Header().Get("Content-Type"))
+	})
+}
+
+func BenchmarkWriteSsz(b *testing.B) {
+	// Roughly the size of a full block at the gossip size limit.
+	v := &testSszValue{data: make([]byte, 10<<20)}
+	b.Run("marshal then write", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			enc, err := v.MarshalSSZ()
+			require.NoError(b, err)
+			WriteSsz(&discardWriter{httptest.NewRecorder()}, enc, "foo.ssz")
+		}
+	})
+	b.Run("pooled buffer", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			require.NoError(b, WriteSszFrom(&discardWriter{httptest.NewRecorder()}, v, "foo.ssz"))
+		}
+	})
+}
+
+// discardWriter is a response writer that drops the body, so that benchmarks only measure the encoding.
+type discardWriter struct {
+	*httptest.ResponseRecorder
+}
+
+func (w *discardWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}